@@ -4,12 +4,19 @@ import (
     "backend-trainee-assignment/internal/app"
     memory "backend-trainee-assignment/internal/infrastructure/persistance/in_memory"
     pg "backend-trainee-assignment/internal/infrastructure/persistance/postgres"
+    "backend-trainee-assignment/internal/infrastructure/webhooks"
     "backend-trainee-assignment/internal/transport/http"
+    "context"
     "database/sql"
+    "fmt"
     "log"
     "math/rand"
     "net/http"
     "os"
+    "os/signal"
+    "strings"
+    "sync"
+    "syscall"
     "time"
 )
 
@@ -40,9 +47,19 @@ func main() {
     }
 
     randSrc := rand.New(rand.NewSource(time.Now().UnixNano()))
-    svc := app.NewService(store, randSrc)
 
-    handler := httpapi.NewHandler(svc)
+    opts, err := reviewerSelectorOptions(store, randSrc)
+    if err != nil {
+        logger.Fatalf("reviewer strategy config error: %v", err)
+    }
+    dispatcher := webhooks.NewDispatcher(store, webhooks.WithLogger(logger))
+    opts = append(opts, app.WithEventPublisher(dispatcher))
+    svc := app.NewService(store, randSrc, opts...)
+
+    handler := httpapi.NewHandler(svc,
+        httpapi.WithGitHubSecret(os.Getenv("GITHUB_WEBHOOK_SECRET")),
+        httpapi.WithGitLabToken(os.Getenv("GITLAB_WEBHOOK_TOKEN")),
+    )
 
     server := &http.Server{
         Addr:         ":8080",
@@ -52,8 +69,78 @@ func main() {
         IdleTimeout:  60 * time.Second,
     }
 
-    logger.Println("starting HTTP server on :8080")
-    if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-        logger.Fatalf("server error: %v", err)
+    slaInterval, err := durationFromEnv("SLA_CHECK_INTERVAL", time.Minute)
+    if err != nil {
+        logger.Fatalf("SLA worker config error: %v", err)
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    var wg sync.WaitGroup
+    wg.Add(1)
+    go func() {
+        defer wg.Done()
+        app.NewSLAWorker(svc, slaInterval, logger).Run(ctx)
+    }()
+
+    go func() {
+        logger.Println("starting HTTP server on :8080")
+        if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+            logger.Fatalf("server error: %v", err)
+        }
+    }()
+
+    <-ctx.Done()
+    logger.Println("shutting down")
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    if err := server.Shutdown(shutdownCtx); err != nil {
+        logger.Printf("server shutdown error: %v", err)
+    }
+    wg.Wait()
+    if err := dispatcher.Close(shutdownCtx); err != nil {
+        logger.Printf("webhook dispatcher shutdown error: %v", err)
+    }
+}
+
+// durationFromEnv parses the variable as a Go duration ("30s", "5m").
+func durationFromEnv(name string, def time.Duration) (time.Duration, error) {
+    v := strings.TrimSpace(os.Getenv(name))
+    if v == "" {
+        return def, nil
+    }
+    d, err := time.ParseDuration(v)
+    if err != nil || d <= 0 {
+        return 0, fmt.Errorf("%s must be a positive duration, got %q", name, v)
+    }
+    return d, nil
+}
+
+// reviewerSelectorOptions reads REVIEWER_STRATEGY (deployment default) and
+// REVIEWER_STRATEGY_OVERRIDES ("team=strategy,team2=strategy") from the environment.
+func reviewerSelectorOptions(store app.Store, r *rand.Rand) ([]app.Option, error) {
+    sel, err := app.NewReviewerSelector(os.Getenv("REVIEWER_STRATEGY"), store, r)
+    if err != nil {
+        return nil, err
+    }
+    opts := []app.Option{app.WithReviewerSelector(sel)}
+
+    overrides := strings.TrimSpace(os.Getenv("REVIEWER_STRATEGY_OVERRIDES"))
+    if overrides == "" {
+        return opts, nil
+    }
+    for _, pair := range strings.Split(overrides, ",") {
+        team, strategy, ok := strings.Cut(pair, "=")
+        if !ok {
+            return nil, fmt.Errorf("invalid override %q, expected team=strategy", pair)
+        }
+        teamSel, err := app.NewReviewerSelector(strings.TrimSpace(strategy), store, r)
+        if err != nil {
+            return nil, err
+        }
+        opts = append(opts, app.WithTeamReviewerSelector(strings.TrimSpace(team), teamSel))
     }
+    return opts, nil
 }
@@ -0,0 +1,415 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "sort"
+)
+
+// slotRequest describes reviewer slots that have to be filled on a PR.
+type slotRequest struct {
+    teamName   string
+    // ownerTeams are searched before teamName's fallback teams; they
+    // default to teamName alone.
+    ownerTeams []string
+    needed     int
+    // assigned are reviewers staying on the PR; they count towards
+    // the team's role rule.
+    assigned []string
+    // exclude holds users that may not take a slot: the author,
+    // reviewers already on the PR, the reviewer being replaced.
+    exclude map[string]struct{}
+    // changedFiles route slots to the owners declared in the team's CODEOWNERS.
+    changedFiles []string
+    // requiredSkills are covered by the first picks where possible.
+    requiredSkills []string
+    // pending adds open reviews planned earlier in the same batch
+    // but not stored yet to the users' load.
+    pending map[string]int
+}
+
+type slotAssignment struct {
+    reviewerIDs []string
+    // codeOwnerIDs is the subset of reviewerIDs picked as owners of changed files.
+    codeOwnerIDs []string
+    // fallbackIDs is the subset of reviewerIDs taken from fallback teams.
+    fallbackIDs []string
+    // overCapacityIDs is the subset of reviewerIDs assigned beyond their
+    // open review limit because the team allows it.
+    overCapacityIDs []string
+    // uncoveredSkills are required skills none of the reviewers has.
+    uncoveredSkills []string
+    // missingRoleReviewers counts role rule slots nobody could fill.
+    missingRoleReviewers int
+}
+
+// slotFiller carries the state of a single fillReviewerSlots call.
+type slotFiller struct {
+    s       *Service
+    exclude map[string]struct{}
+    // atCapacity collects otherwise eligible users skipped for being full.
+    atCapacity map[string]*domain.User
+    limits     map[string]int
+    pending    map[string]int
+}
+
+// fillReviewerSlots gives slots to code owners of the changed files first,
+// then satisfies the home team's role rule and picks the remaining slots
+// from the owner teams and, if they cannot fill every slot, from the home
+// team's fallback teams in declared order. Users at their open review limit
+// are skipped; if that leaves slots empty, the home team's AllowOverCapacity
+// decides between assigning them anyway and failing with ALL_AT_CAPACITY
+// when nobody could be assigned at all.
+func (s *Service) fillReviewerSlots(ctx context.Context, req slotRequest) (slotAssignment, error) {
+    res := slotAssignment{}
+    if req.needed <= 0 {
+        return res, nil
+    }
+
+    f := &slotFiller{
+        s:          s,
+        exclude:    make(map[string]struct{}, len(req.exclude)),
+        atCapacity: make(map[string]*domain.User),
+        limits:     make(map[string]int),
+        pending:    req.pending,
+    }
+    for id := range req.exclude {
+        f.exclude[id] = struct{}{}
+    }
+
+    uncovered := stringSet(req.requiredSkills)
+
+    res.codeOwnerIDs = f.pickCodeOwners(ctx, req.teamName, req.changedFiles, req.needed)
+    res.reviewerIDs = append(res.reviewerIDs, res.codeOwnerIDs...)
+    for _, id := range res.codeOwnerIDs {
+        if u, ok := s.store.GetUserByID(ctx, id); ok {
+            coverSkills(uncovered, u)
+        }
+    }
+
+    settings := s.teamSettings(ctx, req.teamName)
+    owners := req.ownerTeams
+    if len(owners) == 0 {
+        owners = []string{req.teamName}
+    }
+    teams := append([]string(nil), owners...)
+    for _, team := range settings.FallbackTeams {
+        if !containsString(teams, team) {
+            teams = append(teams, team)
+        }
+    }
+
+    missingRole := 0
+    if settings.MinRoleReviewers > 0 {
+        missingRole = settings.MinRoleReviewers - f.countWithRole(ctx, settings.MinReviewerRole, req.assigned, res.reviewerIDs)
+    }
+    for i, team := range teams {
+        remaining := min(missingRole, req.needed-len(res.reviewerIDs))
+        if remaining <= 0 {
+            break
+        }
+
+        members, _ := s.store.ListUsersByTeam(ctx, team)
+        picked := f.pick(ctx, team, withRole(members, settings.MinReviewerRole), remaining, uncovered)
+        missingRole -= len(picked)
+
+        res.reviewerIDs = append(res.reviewerIDs, picked...)
+        if i >= len(owners) {
+            res.fallbackIDs = append(res.fallbackIDs, picked...)
+        }
+    }
+
+    for i, team := range teams {
+        remaining := req.needed - len(res.reviewerIDs)
+        if remaining <= 0 {
+            break
+        }
+
+        members, _ := s.store.ListUsersByTeam(ctx, team)
+        picked := f.pick(ctx, team, members, remaining, uncovered)
+
+        res.reviewerIDs = append(res.reviewerIDs, picked...)
+        if i >= len(owners) {
+            res.fallbackIDs = append(res.fallbackIDs, picked...)
+        }
+    }
+
+    if remaining := req.needed - len(res.reviewerIDs); remaining > 0 && len(f.atCapacity) > 0 {
+        if !settings.AllowOverCapacity {
+            if len(res.reviewerIDs) == 0 {
+                return res, NewAppError(ErrorCodeAllAtCapacity, "all candidates are at their open review limit")
+            }
+        } else {
+            full := make([]*domain.User, 0, len(f.atCapacity))
+            for _, u := range f.atCapacity {
+                if _, skip := f.exclude[u.ID]; !skip {
+                    full = append(full, u)
+                }
+            }
+            sort.Slice(full, func(i, j int) bool { return full[i].ID < full[j].ID })
+            res.overCapacityIDs = s.pickReviewers(ctx, req.teamName, full, remaining)
+            f.take(res.overCapacityIDs)
+            res.reviewerIDs = append(res.reviewerIDs, res.overCapacityIDs...)
+        }
+    }
+
+    if missingRole > 0 {
+        covered := f.countWithRole(ctx, settings.MinReviewerRole, req.assigned, res.reviewerIDs)
+        res.missingRoleReviewers = max(0, settings.MinRoleReviewers-covered)
+    }
+
+    for _, skill := range req.requiredSkills {
+        if _, missing := uncovered[skill]; missing {
+            res.uncoveredSkills = append(res.uncoveredSkills, skill)
+        }
+    }
+
+    return res, nil
+}
+
+// pick takes up to limit available users, covering required skills first.
+func (f *slotFiller) pick(ctx context.Context, team string, users []*domain.User, limit int, uncovered map[string]struct{}) []string {
+    candidates := f.available(ctx, users)
+    picked := f.s.pickBySkills(ctx, team, candidates, limit, uncovered)
+    f.take(picked)
+
+    rest := f.available(ctx, candidates)
+    more := f.s.pickReviewers(ctx, team, rest, limit-len(picked))
+    f.take(more)
+    return append(picked, more...)
+}
+
+// countWithRole counts reviewers across lists holding at least role.
+func (f *slotFiller) countWithRole(ctx context.Context, role domain.Role, lists ...[]string) int {
+    n := 0
+    for id := range stringSet(lists...) {
+        if u, ok := f.s.store.GetUserByID(ctx, id); ok && u.Role.AtLeast(role) {
+            n++
+        }
+    }
+    return n
+}
+
+func withRole(users []*domain.User, role domain.Role) []*domain.User {
+    res := make([]*domain.User, 0, len(users))
+    for _, u := range users {
+        if u != nil && u.Role.AtLeast(role) {
+            res = append(res, u)
+        }
+    }
+    return res
+}
+
+// available applies every eligibility rule, remembering users that
+// were dropped only because they are at capacity.
+func (f *slotFiller) available(ctx context.Context, users []*domain.User) []*domain.User {
+    eligible := f.s.filterEligible(ctx, users, f.exclude)
+    if len(eligible) == 0 {
+        return eligible
+    }
+
+    loads, err := f.s.store.CountOpenReviews(ctx, userIDs(eligible))
+    if err != nil {
+        return eligible
+    }
+
+    res := eligible[:0]
+    for _, u := range eligible {
+        if limit := f.limit(ctx, u); limit > 0 && loads[u.ID]+f.pending[u.ID] >= limit {
+            f.atCapacity[u.ID] = u
+            continue
+        }
+        res = append(res, u)
+    }
+    return res
+}
+
+// limit returns the user's open review limit, falling back to the
+// default of the user's team; zero means unlimited.
+func (f *slotFiller) limit(ctx context.Context, u *domain.User) int {
+    if u.MaxOpenReviews > 0 {
+        return u.MaxOpenReviews
+    }
+    limit, ok := f.limits[u.TeamName]
+    if !ok {
+        limit = f.s.teamSettings(ctx, u.TeamName).DefaultMaxOpenReviews
+        f.limits[u.TeamName] = limit
+    }
+    return limit
+}
+
+func (f *slotFiller) take(ids []string) {
+    for _, id := range ids {
+        f.exclude[id] = struct{}{}
+    }
+}
+
+// pickBySkills greedily picks the candidate covering the most still uncovered
+// skills, letting the selector break ties, until every skill is covered or
+// limit is reached. Covered skills are removed from uncovered.
+func (s *Service) pickBySkills(ctx context.Context, teamName string, candidates []*domain.User, limit int, uncovered map[string]struct{}) []string {
+    picked := make([]string, 0, limit)
+    for len(picked) < limit && len(uncovered) > 0 {
+        best := make([]*domain.User, 0)
+        bestScore := 0
+        for _, u := range candidates {
+            if containsString(picked, u.ID) {
+                continue
+            }
+            score := 0
+            for skill := range uncovered {
+                if u.HasSkill(skill) {
+                    score++
+                }
+            }
+            if score == 0 || score < bestScore {
+                continue
+            }
+            if score > bestScore {
+                best, bestScore = best[:0], score
+            }
+            best = append(best, u)
+        }
+        if len(best) == 0 {
+            break
+        }
+
+        id := s.pickReviewers(ctx, teamName, best, 1)[0]
+        for _, u := range best {
+            if u.ID == id {
+                coverSkills(uncovered, u)
+            }
+        }
+        picked = append(picked, id)
+    }
+    return picked
+}
+
+func coverSkills(uncovered map[string]struct{}, u *domain.User) {
+    for _, skill := range u.Skills {
+        delete(uncovered, skill)
+    }
+}
+
+// pickCodeOwners takes one available owner for every ownership rule matched
+// by the changed files, stopping once needed reviewers are picked.
+func (f *slotFiller) pickCodeOwners(ctx context.Context, teamName string, files []string, needed int) []string {
+    if len(files) == 0 {
+        return nil
+    }
+
+    content, ok := f.s.store.GetCodeOwners(ctx, teamName)
+    if !ok {
+        return nil
+    }
+    co, err := ParseCodeOwners(content)
+    if err != nil {
+        return nil
+    }
+
+    picked := make([]string, 0, needed)
+    seenRules := make(map[string]struct{})
+    for _, file := range files {
+        if len(picked) >= needed {
+            break
+        }
+
+        pattern, owners, ok := co.Match(file)
+        if !ok || len(owners) == 0 {
+            continue
+        }
+        if _, seen := seenRules[pattern]; seen {
+            continue
+        }
+        seenRules[pattern] = struct{}{}
+
+        if containsAny(picked, owners) {
+            continue
+        }
+
+        users := make([]*domain.User, 0, len(owners))
+        for _, id := range owners {
+            if u, ok := f.s.store.GetUserByID(ctx, id); ok {
+                users = append(users, u)
+            }
+        }
+
+        owner := f.s.pickReviewers(ctx, teamName, f.available(ctx, users), 1)
+        f.take(owner)
+        picked = append(picked, owner...)
+    }
+
+    return picked
+}
+
+// reviewerExclusions lists users that may not take a slot on pr: the author,
+// current reviewers, the PR's own exclusions and everyone in a conflict of
+// interest with the author.
+func (s *Service) reviewerExclusions(ctx context.Context, pr *domain.PullRequest) map[string]struct{} {
+    exclude := stringSet([]string{pr.AuthorID}, pr.AssignedReviewers, pr.ExcludedReviewers)
+    for _, e := range s.store.ListReviewExclusions(ctx, pr.AuthorID) {
+        exclude[e.Other(pr.AuthorID)] = struct{}{}
+    }
+    return exclude
+}
+
+// autoExclusions extends reviewerExclusions with reviewers who declined pr,
+// so automatic assignment never hands it back to them. They may still
+// volunteer for it again.
+func (s *Service) autoExclusions(ctx context.Context, pr *domain.PullRequest) map[string]struct{} {
+    exclude := s.reviewerExclusions(ctx, pr)
+    for _, d := range s.store.ListReviewDeclines(ctx, pr.Repository, pr.ID) {
+        exclude[d.UserID] = struct{}{}
+    }
+    return exclude
+}
+
+// filterEligible keeps active users that are not excluded and not
+// inside an unavailability window right now.
+func (s *Service) filterEligible(ctx context.Context, users []*domain.User, exclude map[string]struct{}) []*domain.User {
+    candidates := make([]*domain.User, 0, len(users))
+    for _, u := range users {
+        if u == nil || !u.IsActive {
+            continue
+        }
+        if _, skip := exclude[u.ID]; skip {
+            continue
+        }
+        candidates = append(candidates, u)
+    }
+    if len(candidates) == 0 {
+        return candidates
+    }
+
+    unavailable, err := s.store.UnavailableUserIDs(ctx, userIDs(candidates), s.now())
+    if err != nil || len(unavailable) == 0 {
+        return candidates
+    }
+
+    available := candidates[:0]
+    for _, u := range candidates {
+        if _, away := unavailable[u.ID]; !away {
+            available = append(available, u)
+        }
+    }
+    return available
+}
+
+func stringSet(lists ...[]string) map[string]struct{} {
+    set := make(map[string]struct{})
+    for _, list := range lists {
+        for _, v := range list {
+            set[v] = struct{}{}
+        }
+    }
+    return set
+}
+
+func containsAny(list, targets []string) bool {
+    for _, t := range targets {
+        if containsString(list, t) {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "reflect"
+    "testing"
+)
+
+func TestCreatePullRequestUsesReviewersRequired(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+    if len(pr.AssignedReviewers) != domain.DefaultReviewersRequired {
+        t.Fatalf("got %v, want %d reviewers by default", pr.AssignedReviewers, domain.DefaultReviewersRequired)
+    }
+
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(3)})
+    pr = mustCreatePR(t, s, CreatePullRequestInput{ID: "p2", AuthorID: "a"})
+    if len(pr.AssignedReviewers) != 3 {
+        t.Fatalf("got %v, want 3 reviewers", pr.AssignedReviewers)
+    }
+    for _, id := range pr.AssignedReviewers {
+        if id == "a" {
+            t.Fatal("author was assigned to their own PR")
+        }
+    }
+
+    _, err := s.UpdateTeamSettings(context.Background(), "core", TeamSettingsInput{ReviewersRequired: intPtr(0)})
+    if !isAppError(err, ErrorCodeBadRequest) {
+        t.Fatalf("reviewers_required=0: got %v, want BAD_REQUEST", err)
+    }
+}
+
+func TestCreatePullRequestFallsBackToOtherTeams(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustCreateTeam(t, s, "backup", member("x"))
+    fallback := []string{"backup"}
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{FallbackTeams: &fallback})
+
+    res, err := s.CreatePullRequest(context.Background(), CreatePullRequestInput{ID: "p1", Name: "p1", AuthorID: "a"})
+    if err != nil {
+        t.Fatalf("CreatePullRequest: %v", err)
+    }
+    if got := res.PR.AssignedReviewers; len(got) != 2 || got[0] != "b" || got[1] != "x" {
+        t.Fatalf("reviewers %v, want own team first, then the fallback", got)
+    }
+    if got := res.FallbackReviewerIDs; len(got) != 1 || got[0] != "x" {
+        t.Fatalf("fallback reviewers %v, want [x]", got)
+    }
+
+    own := []string{"core"}
+    _, err = s.UpdateTeamSettings(context.Background(), "core", TeamSettingsInput{FallbackTeams: &own})
+    if !isAppError(err, ErrorCodeBadRequest) {
+        t.Fatalf("self fallback: got %v, want BAD_REQUEST", err)
+    }
+}
+
+func TestCreatePullRequestCoversRequiredSkills(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+    skills := []string{" SQL", "go", "sql"}
+    user, err := s.UpdateUser(context.Background(), "d", UserUpdateInput{Skills: &skills})
+    if err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    if want := []string{"sql", "go"}; !reflect.DeepEqual(user.Skills, want) {
+        t.Fatalf("skills %v, want normalized %v", user.Skills, want)
+    }
+
+    for _, id := range []string{"p1", "p2", "p3"} {
+        res, err := s.CreatePullRequest(context.Background(), CreatePullRequestInput{
+            ID:             id,
+            Name:           id,
+            AuthorID:       "a",
+            RequiredSkills: []string{"sql", "rust"},
+        })
+        if err != nil {
+            t.Fatalf("CreatePullRequest(%s): %v", id, err)
+        }
+        if !containsString(res.PR.AssignedReviewers, "d") {
+            t.Fatalf("%s: reviewers %v, want the sql reviewer d", id, res.PR.AssignedReviewers)
+        }
+        if !reflect.DeepEqual(res.UncoveredSkills, []string{"rust"}) {
+            t.Fatalf("%s: uncovered %v, want [rust]", id, res.UncoveredSkills)
+        }
+    }
+}
+
+func TestCreatePullRequestRespectsReviewLimits(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{DefaultMaxOpenReviews: intPtr(1)})
+
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+    _, err := s.CreatePullRequest(context.Background(), CreatePullRequestInput{ID: "p2", Name: "p2", AuthorID: "a"})
+    if !isAppError(err, ErrorCodeAllAtCapacity) {
+        t.Fatalf("b at limit: got %v, want ALL_AT_CAPACITY", err)
+    }
+
+    if _, err := s.UpdateUser(context.Background(), "b", UserUpdateInput{MaxOpenReviews: intPtr(2)}); err != nil {
+        t.Fatalf("UpdateUser: %v", err)
+    }
+    res, err := s.CreatePullRequest(context.Background(), CreatePullRequestInput{ID: "p3", Name: "p3", AuthorID: "a"})
+    if err != nil || len(res.OverCapacityReviewerIDs) != 0 {
+        t.Fatalf("personal limit 2: got %+v, %v; want b within limit", res, err)
+    }
+
+    allow := true
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{AllowOverCapacity: &allow})
+    res, err = s.CreatePullRequest(context.Background(), CreatePullRequestInput{ID: "p4", Name: "p4", AuthorID: "a"})
+    if err != nil {
+        t.Fatalf("CreatePullRequest with over capacity allowed: %v", err)
+    }
+    if !reflect.DeepEqual(res.OverCapacityReviewerIDs, []string{"b"}) || !reflect.DeepEqual(res.PR.AssignedReviewers, []string{"b"}) {
+        t.Fatalf("got reviewers %v, over capacity %v; want b over capacity", res.PR.AssignedReviewers, res.OverCapacityReviewerIDs)
+    }
+}
+
+func TestCreatePullRequestFillsRoleRule(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core",
+        memberAs("a", domain.RoleJunior), memberAs("b", domain.RoleJunior),
+        memberAs("c", domain.RoleJunior), memberAs("d", domain.RoleJunior),
+        memberAs("e", domain.RoleSenior))
+    senior := domain.RoleSenior
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{MinReviewerRole: &senior, MinRoleReviewers: intPtr(1)})
+
+    for _, id := range []string{"p1", "p2", "p3"} {
+        pr := mustCreatePR(t, s, CreatePullRequestInput{ID: id, AuthorID: "a"})
+        if len(pr.AssignedReviewers) != 2 || !containsString(pr.AssignedReviewers, "e") {
+            t.Fatalf("%s: reviewers %v, want the senior e and one more", id, pr.AssignedReviewers)
+        }
+    }
+
+    if _, err := s.SetUserIsActive(context.Background(), "e", false, false); err != nil {
+        t.Fatalf("SetUserIsActive: %v", err)
+    }
+    res, err := s.CreatePullRequest(context.Background(), CreatePullRequestInput{ID: "p4", Name: "p4", AuthorID: "a"})
+    if err != nil {
+        t.Fatalf("CreatePullRequest: %v", err)
+    }
+    if len(res.PR.AssignedReviewers) != 2 || res.MissingRoleReviewers != 1 {
+        t.Fatalf("got reviewers %v, missing %d; want two juniors and one missing senior", res.PR.AssignedReviewers, res.MissingRoleReviewers)
+    }
+
+    _, err = s.UpdateTeamSettings(context.Background(), "core", TeamSettingsInput{MinRoleReviewers: intPtr(3)})
+    if !isAppError(err, ErrorCodeBadRequest) {
+        t.Fatalf("min_role_reviewers above reviewers_required: got %v, want BAD_REQUEST", err)
+    }
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+    "bufio"
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+// CodeOwners is a parsed CODEOWNERS file. Owners are user IDs, optionally
+// prefixed with "@". As in GitHub, the last matching rule wins.
+type CodeOwners struct {
+    rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+    pattern string
+    re      *regexp.Regexp
+    owners  []string
+}
+
+func ParseCodeOwners(content string) (*CodeOwners, error) {
+    co := &CodeOwners{}
+
+    scanner := bufio.NewScanner(strings.NewReader(content))
+    lineNo := 0
+    for scanner.Scan() {
+        lineNo++
+        line := scanner.Text()
+        if i := strings.Index(line, "#"); i >= 0 {
+            line = line[:i]
+        }
+        fields := strings.Fields(line)
+        if len(fields) == 0 {
+            continue
+        }
+
+        pattern := fields[0]
+        if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+            return nil, fmt.Errorf("line %d: unsupported pattern %q", lineNo, pattern)
+        }
+
+        owners := make([]string, 0, len(fields)-1)
+        for _, owner := range fields[1:] {
+            owner = strings.TrimPrefix(owner, "@")
+            if owner == "" {
+                return nil, fmt.Errorf("line %d: empty owner", lineNo)
+            }
+            owners = append(owners, owner)
+        }
+
+        co.rules = append(co.rules, codeOwnersRule{
+            pattern: pattern,
+            re:      compileCodeOwnersPattern(pattern),
+            owners:  owners,
+        })
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+
+    return co, nil
+}
+
+// Match returns the rule pattern and owners applying to path.
+// ok is false when no rule matches.
+func (co *CodeOwners) Match(path string) (pattern string, owners []string, ok bool) {
+    path = strings.TrimPrefix(path, "/")
+    for i := len(co.rules) - 1; i >= 0; i-- {
+        if co.rules[i].re.MatchString(path) {
+            return co.rules[i].pattern, co.rules[i].owners, true
+        }
+    }
+    return "", nil, false
+}
+
+// compileCodeOwnersPattern follows gitignore rules: a pattern without an inner
+// slash matches at any depth, a matching directory covers everything below it.
+func compileCodeOwnersPattern(pattern string) *regexp.Regexp {
+    anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+    dirOnly := strings.HasSuffix(pattern, "/")
+    pattern = strings.Trim(pattern, "/")
+
+    var b strings.Builder
+    if anchored {
+        b.WriteString("^")
+    } else {
+        b.WriteString("^(?:.*/)?")
+    }
+
+    for i := 0; i < len(pattern); i++ {
+        switch c := pattern[i]; c {
+        case '*':
+            if i+1 < len(pattern) && pattern[i+1] == '*' {
+                i++
+                if i+1 < len(pattern) && pattern[i+1] == '/' {
+                    i++
+                    b.WriteString("(?:.*/)?")
+                } else {
+                    b.WriteString(".*")
+                }
+            } else {
+                b.WriteString("[^/]*")
+            }
+        case '?':
+            b.WriteString("[^/]")
+        default:
+            b.WriteString(regexp.QuoteMeta(string(c)))
+        }
+    }
+
+    if dirOnly {
+        b.WriteString("/.*$")
+    } else {
+        b.WriteString("(?:/.*)?$")
+    }
+
+    return regexp.MustCompile(b.String())
+}
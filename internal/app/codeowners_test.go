@@ -0,0 +1,70 @@
+package app
+
+import (
+    "context"
+    "reflect"
+    "testing"
+)
+
+func TestCodeOwnersMatch(t *testing.T) {
+    co, err := ParseCodeOwners(`
+# default owners
+*            @lead
+*.go         gopher
+/docs/       writer  # top-level docs only
+api/**/v1.go api-a api-b
+`)
+    if err != nil {
+        t.Fatalf("ParseCodeOwners: %v", err)
+    }
+
+    tests := []struct {
+        path   string
+        owners []string
+    }{
+        {"README.md", []string{"lead"}},
+        {"internal/app/service.go", []string{"gopher"}},
+        {"docs/guide.md", []string{"writer"}},
+        {"pkg/docs/guide.md", []string{"lead"}},
+        {"api/v1.go", []string{"api-a", "api-b"}},
+        {"api/users/http/v1.go", []string{"api-a", "api-b"}},
+        {"/api/v1.go", []string{"api-a", "api-b"}},
+    }
+    for _, tt := range tests {
+        _, owners, ok := co.Match(tt.path)
+        if !ok || !reflect.DeepEqual(owners, tt.owners) {
+            t.Errorf("Match(%q) = %v, %v; want %v", tt.path, owners, ok, tt.owners)
+        }
+    }
+}
+
+func TestParseCodeOwnersRejectsUnsupportedPatterns(t *testing.T) {
+    for _, content := range []string{"!*.go a", "[ab].go a", "*.go @"} {
+        if _, err := ParseCodeOwners(content); err == nil {
+            t.Errorf("ParseCodeOwners(%q) succeeded", content)
+        }
+    }
+}
+
+func TestCreatePullRequestAssignsCodeOwners(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+    if err := s.SetCodeOwners(context.Background(), "core", "/migrations/ @d\n"); err != nil {
+        t.Fatalf("SetCodeOwners: %v", err)
+    }
+
+    for i, id := range []string{"p1", "p2", "p3"} {
+        res, err := s.CreatePullRequest(context.Background(), CreatePullRequestInput{
+            ID:           id,
+            Name:         id,
+            AuthorID:     "a",
+            ChangedFiles: []string{"migrations/00" + string(rune('1'+i)) + ".sql"},
+        })
+        if err != nil {
+            t.Fatalf("CreatePullRequest(%s): %v", id, err)
+        }
+        if !reflect.DeepEqual(res.CodeOwnerReviewerIDs, []string{"d"}) || !containsString(res.PR.AssignedReviewers, "d") {
+            t.Fatalf("%s: reviewers %v, code owners %v; want d assigned as owner", id, res.PR.AssignedReviewers, res.CodeOwnerReviewerIDs)
+        }
+    }
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    memory "backend-trainee-assignment/internal/infrastructure/persistance/in_memory"
+    "context"
+    "math/rand"
+    "reflect"
+    "strconv"
+    "testing"
+)
+
+func TestDeactivateTeamDryRunMatchesRealRun(t *testing.T) {
+    services := map[string]func() *Service{
+        StrategyRandom: func() *Service {
+            return newTestService(t)
+        },
+        StrategyRoundRobin: func() *Service {
+            return newTestService(t, WithReviewerSelector(NewRoundRobinSelector()))
+        },
+        StrategyLeastLoaded: func() *Service {
+            store := memory.NewInMemoryStore()
+            return NewService(store, rand.New(rand.NewSource(1)), WithReviewerSelector(NewLeastLoadedSelector(store, rand.New(rand.NewSource(2)))))
+        },
+    }
+    for strategy, newService := range services {
+        t.Run(strategy, func(t *testing.T) {
+            ctx := context.Background()
+            s := newService()
+            mustCreateTeam(t, s, "backup", memberAs("z1", domain.RoleSenior), memberAs("z2", domain.RoleSenior), memberAs("z3", domain.RoleSenior))
+            mustCreateTeam(t, s, "core", memberAs("a", domain.RoleSenior), memberAs("b", domain.RoleSenior))
+            mustUpdateSettings(t, s, "core", TeamSettingsInput{
+                ReviewersRequired: intPtr(1),
+                FallbackTeams:     &[]string{"backup"},
+            })
+            for i := 0; i < 4; i++ {
+                mustCreatePR(t, s, CreatePullRequestInput{ID: "p" + strconv.Itoa(i), AuthorID: "a"})
+            }
+
+            first, err := s.DeactivateTeamUsersAndReassignOpenPRs(ctx, "core", true)
+            if err != nil {
+                t.Fatalf("dry run: %v", err)
+            }
+            second, err := s.DeactivateTeamUsersAndReassignOpenPRs(ctx, "core", true)
+            if err != nil {
+                t.Fatalf("second dry run: %v", err)
+            }
+            if !reflect.DeepEqual(first.Changes, second.Changes) {
+                t.Fatalf("dry runs differ: %+v vs %+v", first.Changes, second.Changes)
+            }
+
+            applied, err := s.DeactivateTeamUsersAndReassignOpenPRs(ctx, "core", false)
+            if err != nil {
+                t.Fatalf("real run: %v", err)
+            }
+            if !reflect.DeepEqual(first.Changes, applied.Changes) {
+                t.Fatalf("dry run planned %+v, real run did %+v", first.Changes, applied.Changes)
+            }
+            if len(applied.Changes) != 4 {
+                t.Fatalf("got %d changes, want 4", len(applied.Changes))
+            }
+            for _, c := range applied.Changes {
+                pr := mustGetPR(t, s, c.PullRequestID)
+                if !reflect.DeepEqual(pr.AssignedReviewers, c.NewReviewerIDs) {
+                    t.Errorf("%s has reviewers %v, want %v", pr.ID, pr.AssignedReviewers, c.NewReviewerIDs)
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "strings"
+)
+
+// DeclineReview hands userID's slot to a replacement picked like
+// ReassignReviewer does and records why they turned the review down. The
+// decline is only stored once the replacement is in place; when nobody can
+// take over, the error is returned and the PR is left as it was.
+func (s *Service) DeclineReview(ctx context.Context, repo, prID, userID, reason string) (*ReassignResult, error) {
+    reason = strings.ToLower(strings.TrimSpace(reason))
+    if prID == "" || userID == "" || reason == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "pull_request_id, user_id and reason are required")
+    }
+
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, prID)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := requireOpen(pr, "decline review"); err != nil {
+        return nil, err
+    }
+
+    reviewerIndex := indexOf(pr.AssignedReviewers, userID)
+    if reviewerIndex == -1 {
+        return nil, NewAppError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+    }
+
+    res, err := s.replaceReviewer(ctx, pr, reviewerIndex, "declined: "+reason)
+    if err != nil {
+        return nil, err
+    }
+
+    s.store.AddReviewDecline(ctx, &domain.ReviewDecline{
+        Repository:    pr.Repository,
+        PullRequestID: pr.ID,
+        UserID:        userID,
+        Reason:        reason,
+        CreatedAt:     s.now(),
+    })
+    return res, nil
+}
@@ -1,11 +1,16 @@
 package app
 
-import "backend-trainee-assignment/internal/domain"
+import (
+    "backend-trainee-assignment/internal/domain"
+    "time"
+)
 
 type TeamMemberInput struct {
     UserID   string
     Username string
     IsActive bool
+    // Role defaults to the user's current role, or middle for new users.
+    Role domain.Role
 }
 
 type TeamWithMembers struct {
@@ -13,8 +18,183 @@ type TeamWithMembers struct {
     Members []*domain.User
 }
 
+type UserUpdateInput struct {
+    Skills         *[]string
+    MaxOpenReviews *int
+    Role           *domain.Role
+}
+
+type UnavailabilityInput struct {
+    UserID   string
+    StartsAt time.Time
+    EndsAt   time.Time
+    Reason   string
+}
+
+type ReviewExclusionInput struct {
+    UserID      string
+    OtherUserID string
+    Reason      string
+}
+
+type TeamSettingsInput struct {
+    ReviewersRequired     *int
+    FallbackTeams         *[]string
+    DefaultMaxOpenReviews *int
+    AllowOverCapacity     *bool
+    MinReviewerRole       *domain.Role
+    MinRoleReviewers      *int
+    RequiredApprovals     *int
+    ReviewSLA             *time.Duration
+    SLAAction             *domain.SLAAction
+}
+
+type CreatePullRequestInput struct {
+    // Repository is empty for PRs outside any repository.
+    Repository          string
+    ID                  string
+    Name                string
+    AuthorID            string
+    ChangedFiles        []string
+    RequiredSkills      []string
+    ExcludedReviewerIDs []string
+    Draft               bool
+    Description         string
+    Labels              []string
+    SourceBranch        string
+    TargetBranch        string
+    ExternalURL         string
+}
+
+type SubmitReviewInput struct {
+    Repository    string
+    PullRequestID string
+    ReviewerID    string
+    Decision      domain.ReviewDecision
+    Comment       string
+}
+
+type MarkReadyInput struct {
+    Repository     string
+    ID             string
+    ChangedFiles   []string
+    RequiredSkills []string
+}
+
+type CreatePullRequestResult struct {
+    PR                      *domain.PullRequest
+    CodeOwnerReviewerIDs    []string
+    FallbackReviewerIDs     []string
+    OverCapacityReviewerIDs []string
+    UncoveredSkills         []string
+    // MissingRoleReviewers counts slots of the team's role rule
+    // that no eligible reviewer could fill.
+    MissingRoleReviewers int
+}
+
+type ReassignResult struct {
+    PR                      *domain.PullRequest
+    ReplacedBy              string
+    FallbackReviewerIDs     []string
+    OverCapacityReviewerIDs []string
+}
+
+// PullRequestRef identifies a PR across repositories.
+type PullRequestRef struct {
+    Repository string
+    ID         string
+}
+
+type TeamMemberResult struct {
+    User                *domain.User
+    UpdatedPullRequests []PullRequestRef
+}
+
 type DeactivateTeamResult struct {
-    TeamName              string
-    DeactivatedUserIDs    []string
-    UpdatedPullRequestIDs []string
-}
\ No newline at end of file
+    TeamName            string
+    DryRun              bool
+    DeactivatedUserIDs  []string
+    UpdatedPullRequests []PullRequestRef
+    Changes             []ReviewerChange
+}
+
+type ReviewerChange struct {
+    Repository     string
+    PullRequestID  string
+    OldReviewerIDs []string
+    NewReviewerIDs []string
+}
+type ReviewerMove struct {
+    Repository    string
+    PullRequestID string
+    FromUserID    string
+    ToUserID      string
+}
+
+// SLAEscalation describes a review that missed its team's SLA and what
+// was done about it.
+type SLAEscalation struct {
+    Repository    string
+    PullRequestID string
+    ReviewerID    string
+    Action        domain.SLAAction
+    // NewReviewerID took over the slot or joined the PR.
+    NewReviewerID string
+}
+
+type RebalanceResult struct {
+    TeamName string
+    DryRun   bool
+    Moves    []ReviewerMove
+}
+
+// IngestAction is what happened to a PR on the code host.
+type IngestAction string
+
+const (
+    IngestOpened   IngestAction = "opened"
+    IngestReady    IngestAction = "ready"
+    IngestClosed   IngestAction = "closed"
+    IngestMerged   IngestAction = "merged"
+    IngestReopened IngestAction = "reopened"
+)
+
+// IngestPullRequestInput is a PR event received from GitHub or GitLab.
+// Logins are the provider's; they are mapped to our users through the
+// identity table.
+type IngestPullRequestInput struct {
+    Provider     domain.Provider
+    Action       IngestAction
+    Repository   string
+    ID           string
+    Name         string
+    AuthorLogin  string
+    SenderLogin  string
+    Draft        bool
+    Description  string
+    Labels       []string
+    SourceBranch string
+    TargetBranch string
+    ExternalURL  string
+}
+
+// ListPullRequestsInput filters and pages /pullRequest/list. Empty filters
+// match every PR; Cursor is the NextCursor of the previous page.
+type ListPullRequestsInput struct {
+    Statuses    []domain.PRStatus
+    AuthorID    string
+    ReviewerID  string
+    TeamName    string
+    CreatedFrom time.Time
+    CreatedTo   time.Time
+    Sort        domain.PRSortField
+    Desc        bool
+    Cursor      string
+    Limit       int
+}
+
+type PullRequestPage struct {
+    PRs []*domain.PullRequest
+    // NextCursor is empty on the last page.
+    NextCursor string
+}
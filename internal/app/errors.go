@@ -1,15 +1,25 @@
 package app
 
+import "errors"
+
 type ErrorCode string
 
 const (
-    ErrorCodeTeamExists  ErrorCode = "TEAM_EXISTS"
-    ErrorCodePRExists    ErrorCode = "PR_EXISTS"
-    ErrorCodePRMerged    ErrorCode = "PR_MERGED"
-    ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
-    ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
-    ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
-    ErrorCodeBadRequest  ErrorCode = "BAD_REQUEST"
+    ErrorCodeTeamExists          ErrorCode = "TEAM_EXISTS"
+    ErrorCodePRExists            ErrorCode = "PR_EXISTS"
+    ErrorCodeRepositoryExists    ErrorCode = "REPOSITORY_EXISTS"
+    ErrorCodePRMerged            ErrorCode = "PR_MERGED"
+    ErrorCodePRNotOpen           ErrorCode = "PR_NOT_OPEN"
+    ErrorCodeInvalidTransition   ErrorCode = "INVALID_STATUS_TRANSITION"
+    ErrorCodeMergeBlocked        ErrorCode = "MERGE_BLOCKED"
+    ErrorCodeNotAssigned         ErrorCode = "NOT_ASSIGNED"
+    ErrorCodeAlreadyAssigned     ErrorCode = "ALREADY_ASSIGNED"
+    ErrorCodeReviewerNotEligible ErrorCode = "REVIEWER_NOT_ELIGIBLE"
+    ErrorCodeNoCandidate         ErrorCode = "NO_CANDIDATE"
+    ErrorCodeAllAtCapacity       ErrorCode = "ALL_AT_CAPACITY"
+    ErrorCodeExclusionExists     ErrorCode = "EXCLUSION_EXISTS"
+    ErrorCodeNotFound            ErrorCode = "NOT_FOUND"
+    ErrorCodeBadRequest          ErrorCode = "BAD_REQUEST"
 )
 
 type AppError struct {
@@ -27,3 +37,8 @@ func NewAppError(code ErrorCode, msg string) *AppError {
         Message: msg,
     }
 }
+
+func isAppError(err error, code ErrorCode) bool {
+    var appErr *AppError
+    return errors.As(err, &appErr) && appErr.Code == code
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+// Reasons recorded with reviewer events.
+const (
+    reasonCreated     = "created"
+    reasonReady       = "marked ready"
+    reasonReopened    = "reopened"
+    reasonReassigned  = "reassigned"
+    reasonManual      = "manual"
+    reasonSelfAssign  = "self-assigned"
+    reasonDeactivated = "reviewer deactivated"
+    reasonTopUp       = "top up"
+    reasonRebalance   = "rebalance"
+    reasonSLA         = "review SLA missed"
+)
+
+type actorKey struct{}
+
+// WithActor marks ctx as carrying a request made by actorID. Events
+// recorded under ctx are attributed to that actor.
+func WithActor(ctx context.Context, actorID string) context.Context {
+    return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+func actorFrom(ctx context.Context) string {
+    actorID, _ := ctx.Value(actorKey{}).(string)
+    return actorID
+}
+
+// PullRequestHistory returns the PR's events, oldest first.
+func (s *Service) PullRequestHistory(ctx context.Context, repo, prID string) ([]*domain.PREvent, error) {
+    if _, ok := s.store.GetPullRequestByID(ctx, repo, prID); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    return s.store.ListPREvents(ctx, repo, prID), nil
+}
+
+// createPullRequest stores a new pr and records its creation along with
+// the reviewers it starts with.
+func (s *Service) createPullRequest(ctx context.Context, pr *domain.PullRequest) bool {
+    if !s.store.CreatePullRequest(ctx, pr) {
+        return false
+    }
+    s.recordEvent(ctx, pr, domain.PREvent{Type: domain.EventCreated, ToStatus: pr.Status})
+    s.recordReviewerEvents(ctx, pr, nil, reasonCreated)
+    return true
+}
+
+// updatePullRequest stores pr, bumping its UpdatedAt, and records how its
+// status and reviewers changed compared to the stored version.
+func (s *Service) updatePullRequest(ctx context.Context, pr *domain.PullRequest, reason string) bool {
+    prev, ok := s.store.GetPullRequestByID(ctx, pr.Repository, pr.ID)
+    if !ok {
+        return false
+    }
+
+    pr.UpdatedAt = s.now()
+    if !s.store.UpdatePullRequest(ctx, pr) {
+        return false
+    }
+
+    if prev.Status != pr.Status {
+        eventType := domain.EventStatusChanged
+        if pr.Status == domain.StatusMerged {
+            eventType = domain.EventMerged
+        }
+        s.recordEvent(ctx, pr, domain.PREvent{Type: eventType, FromStatus: prev.Status, ToStatus: pr.Status})
+    }
+    s.recordReviewerEvents(ctx, pr, prev.AssignedReviewers, reason)
+    return true
+}
+
+// recordReviewerEvents pairs reviewers that left the PR with the ones that
+// joined it, in order, as replacements; the rest are plain removals or
+// assignments.
+func (s *Service) recordReviewerEvents(ctx context.Context, pr *domain.PullRequest, old []string, reason string) {
+    var removed, added []string
+    for _, id := range old {
+        if !containsString(pr.AssignedReviewers, id) {
+            removed = append(removed, id)
+        }
+    }
+    for _, id := range pr.AssignedReviewers {
+        if !containsString(old, id) {
+            added = append(added, id)
+        }
+    }
+
+    for i := 0; i < max(len(removed), len(added)); i++ {
+        e := domain.PREvent{Reason: reason}
+        switch {
+        case i < len(removed) && i < len(added):
+            e.Type, e.OldReviewerID, e.NewReviewerID = domain.EventReviewerReplaced, removed[i], added[i]
+        case i < len(removed):
+            e.Type, e.OldReviewerID = domain.EventReviewerRemoved, removed[i]
+        default:
+            e.Type, e.NewReviewerID = domain.EventReviewerAssigned, added[i]
+        }
+        s.recordEvent(ctx, pr, e)
+    }
+}
+
+func (s *Service) recordEvent(ctx context.Context, pr *domain.PullRequest, e domain.PREvent) {
+    e.Repository = pr.Repository
+    e.PullRequestID = pr.ID
+    e.ActorID = actorFrom(ctx)
+    e.CreatedAt = s.now()
+    if s.store.AddPREvent(ctx, &e) && s.publisher != nil {
+        s.publisher.Publish(ctx, &e)
+    }
+}
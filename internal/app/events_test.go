@@ -0,0 +1,55 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "testing"
+)
+
+func TestPullRequestHistory(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+
+    res, err := s.CreatePullRequest(WithActor(ctx, "a"), CreatePullRequestInput{ID: "p", Name: "p", AuthorID: "a"})
+    if err != nil {
+        t.Fatalf("CreatePullRequest: %v", err)
+    }
+    first := res.PR.AssignedReviewers[0]
+    second := "b"
+    if first == "b" {
+        second = "c"
+    }
+    if _, err := s.ReassignReviewer(WithActor(ctx, "lead"), "", "p", first, second); err != nil {
+        t.Fatalf("ReassignReviewer: %v", err)
+    }
+    if _, err := s.MergePullRequest(ctx, "", "p"); err != nil {
+        t.Fatalf("MergePullRequest: %v", err)
+    }
+
+    events, err := s.PullRequestHistory(ctx, "", "p")
+    if err != nil {
+        t.Fatalf("PullRequestHistory: %v", err)
+    }
+    want := []domain.PREvent{
+        {Type: domain.EventCreated, ActorID: "a", ToStatus: domain.StatusOpen},
+        {Type: domain.EventReviewerAssigned, ActorID: "a", NewReviewerID: first, Reason: reasonCreated},
+        {Type: domain.EventReviewerReplaced, ActorID: "lead", OldReviewerID: first, NewReviewerID: second, Reason: reasonReassigned},
+        {Type: domain.EventMerged, FromStatus: domain.StatusOpen, ToStatus: domain.StatusMerged},
+    }
+    if len(events) != len(want) {
+        t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+    }
+    for i, e := range events {
+        w := want[i]
+        if e.Type != w.Type || e.ActorID != w.ActorID || e.OldReviewerID != w.OldReviewerID ||
+            e.NewReviewerID != w.NewReviewerID || e.FromStatus != w.FromStatus || e.ToStatus != w.ToStatus || e.Reason != w.Reason {
+            t.Errorf("event %d = %+v, want %+v", i, *e, w)
+        }
+    }
+
+    if _, err := s.PullRequestHistory(ctx, "", "missing"); !isAppError(err, ErrorCodeNotFound) {
+        t.Fatalf("history of a missing PR: got %v, want NOT_FOUND", err)
+    }
+}
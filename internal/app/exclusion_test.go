@@ -0,0 +1,37 @@
+package app
+
+import (
+    "context"
+    "reflect"
+    "testing"
+)
+
+func TestExclusionsAreNotAssigned(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+
+    // Stored the other way round: exclusions work in both directions.
+    _, err := s.AddReviewExclusion(context.Background(), ReviewExclusionInput{UserID: "b", OtherUserID: "a", Reason: "pairing"})
+    if err != nil {
+        t.Fatalf("AddReviewExclusion: %v", err)
+    }
+    _, err = s.AddReviewExclusion(context.Background(), ReviewExclusionInput{UserID: "a", OtherUserID: "b"})
+    if !isAppError(err, ErrorCodeExclusionExists) {
+        t.Fatalf("duplicate exclusion: got %v, want EXCLUSION_EXISTS", err)
+    }
+
+    for _, id := range []string{"p1", "p2", "p3"} {
+        pr := mustCreatePR(t, s, CreatePullRequestInput{ID: id, AuthorID: "a", ExcludedReviewerIDs: []string{"c"}})
+        if !reflect.DeepEqual(pr.AssignedReviewers, []string{"d"}) {
+            t.Fatalf("%s: reviewers %v, want only d", id, pr.AssignedReviewers)
+        }
+    }
+
+    if err := s.DeleteReviewExclusion(context.Background(), "a", "b"); err != nil {
+        t.Fatalf("DeleteReviewExclusion: %v", err)
+    }
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p4", AuthorID: "a", ExcludedReviewerIDs: []string{"c"}})
+    if !containsString(pr.AssignedReviewers, "b") {
+        t.Fatalf("reviewers %v after deleting the exclusion, want b back", pr.AssignedReviewers)
+    }
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *Service) AddReviewExclusion(ctx context.Context, in ReviewExclusionInput) (*domain.ReviewExclusion, error) {
+    a, b, err := s.exclusionPair(ctx, in.UserID, in.OtherUserID)
+    if err != nil {
+        return nil, err
+    }
+
+    e := &domain.ReviewExclusion{
+        UserID:      a,
+        OtherUserID: b,
+        Reason:      in.Reason,
+        CreatedAt:   s.now(),
+    }
+    if !s.store.AddReviewExclusion(ctx, e) {
+        return nil, NewAppError(ErrorCodeExclusionExists, "exclusion already exists")
+    }
+    return e, nil
+}
+
+func (s *Service) DeleteReviewExclusion(ctx context.Context, userID, otherUserID string) error {
+    a, b := orderedPair(userID, otherUserID)
+    if !s.store.DeleteReviewExclusion(ctx, a, b) {
+        return NewAppError(ErrorCodeNotFound, "exclusion not found")
+    }
+    return nil
+}
+
+func (s *Service) ListReviewExclusions(ctx context.Context, userID string) []*domain.ReviewExclusion {
+    return s.store.ListReviewExclusions(ctx, userID)
+}
+
+func (s *Service) exclusionPair(ctx context.Context, userID, otherUserID string) (string, string, error) {
+    if userID == "" || otherUserID == "" {
+        return "", "", NewAppError(ErrorCodeBadRequest, "user_id and other_user_id are required")
+    }
+    if userID == otherUserID {
+        return "", "", NewAppError(ErrorCodeBadRequest, "user cannot be excluded from themselves")
+    }
+    for _, id := range []string{userID, otherUserID} {
+        if _, ok := s.store.GetUserByID(ctx, id); !ok {
+            return "", "", NewAppError(ErrorCodeNotFound, "user "+id+" not found")
+        }
+    }
+
+    a, b := orderedPair(userID, otherUserID)
+    return a, b, nil
+}
+
+func orderedPair(a, b string) (string, string) {
+    if b < a {
+        return b, a
+    }
+    return a, b
+}
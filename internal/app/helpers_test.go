@@ -0,0 +1,84 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    memory "backend-trainee-assignment/internal/infrastructure/persistance/in_memory"
+    "context"
+    "math/rand"
+    "sync"
+    "testing"
+    "time"
+)
+
+func newTestService(t *testing.T, opts ...Option) *Service {
+    t.Helper()
+    return NewService(memory.NewInMemoryStore(), rand.New(rand.NewSource(1)), opts...)
+}
+
+func member(id string) TeamMemberInput {
+    return TeamMemberInput{UserID: id, Username: id, IsActive: true}
+}
+
+func memberAs(id string, role domain.Role) TeamMemberInput {
+    return TeamMemberInput{UserID: id, Username: id, IsActive: true, Role: role}
+}
+
+func mustCreateTeam(t *testing.T, s *Service, name string, members ...TeamMemberInput) {
+    t.Helper()
+    if _, err := s.CreateTeam(context.Background(), name, members); err != nil {
+        t.Fatalf("CreateTeam(%s): %v", name, err)
+    }
+}
+
+func mustUpdateSettings(t *testing.T, s *Service, team string, in TeamSettingsInput) {
+    t.Helper()
+    if _, err := s.UpdateTeamSettings(context.Background(), team, in); err != nil {
+        t.Fatalf("UpdateTeamSettings(%s): %v", team, err)
+    }
+}
+
+func mustCreatePR(t *testing.T, s *Service, in CreatePullRequestInput) *domain.PullRequest {
+    t.Helper()
+    if in.Name == "" {
+        in.Name = in.ID
+    }
+    res, err := s.CreatePullRequest(context.Background(), in)
+    if err != nil {
+        t.Fatalf("CreatePullRequest(%s): %v", in.ID, err)
+    }
+    return res.PR
+}
+
+// mustGetPR returns a PR outside repositories.
+func mustGetPR(t *testing.T, s *Service, id string) *domain.PullRequest {
+    t.Helper()
+    pr, ok := s.store.GetPullRequestByID(context.Background(), domain.DefaultRepository, id)
+    if !ok {
+        t.Fatalf("PR %s not found", id)
+    }
+    return pr
+}
+
+func intPtr(v int) *int { return &v }
+
+// fakeClock is a WithClock source that only moves when told to.
+type fakeClock struct {
+    mu  sync.Mutex
+    now time.Time
+}
+
+func newFakeClock() *fakeClock {
+    return &fakeClock{now: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.now = c.now.Add(d)
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "strings"
+)
+
+// SetIdentity maps the provider login to userID, replacing any previous
+// mapping of that login. Logins are case-insensitive.
+func (s *Service) SetIdentity(ctx context.Context, provider domain.Provider, login, userID string) (*domain.ExternalIdentity, error) {
+    if !provider.Valid() {
+        return nil, NewAppError(ErrorCodeBadRequest, "unknown provider "+string(provider))
+    }
+    login = normalizeLogin(login)
+    if login == "" || userID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "login and user_id are required")
+    }
+    if _, ok := s.store.GetUserByID(ctx, userID); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "user not found")
+    }
+
+    id := &domain.ExternalIdentity{Provider: provider, Login: login, UserID: userID}
+    if !s.store.SaveIdentity(ctx, id) {
+        return nil, NewAppError(ErrorCodeNotFound, "user not found")
+    }
+    return id, nil
+}
+
+func (s *Service) DeleteIdentity(ctx context.Context, provider domain.Provider, login string) error {
+    if !s.store.DeleteIdentity(ctx, provider, normalizeLogin(login)) {
+        return NewAppError(ErrorCodeNotFound, "identity not found")
+    }
+    return nil
+}
+
+func (s *Service) ListIdentities(ctx context.Context, provider domain.Provider) ([]*domain.ExternalIdentity, error) {
+    if provider != "" && !provider.Valid() {
+        return nil, NewAppError(ErrorCodeBadRequest, "unknown provider "+string(provider))
+    }
+    return s.store.ListIdentities(ctx, provider), nil
+}
+
+// IngestPullRequest applies a PR event from the code host. PRs are keyed by
+// the host's repository name, which must be registered, and PR number.
+// Events are idempotent so that redelivered webhooks are harmless: opening
+// a known PR or moving a PR to the status it already has returns it as is.
+func (s *Service) IngestPullRequest(ctx context.Context, in IngestPullRequestInput) (*domain.PullRequest, error) {
+    if in.Repository == "" || in.ID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "repository and pull request number are required")
+    }
+    if _, ok := s.store.GetRepository(ctx, in.Repository); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "repository "+in.Repository+" is not registered")
+    }
+
+    // Events are attributed to the sender when we know who that is.
+    if id, ok := s.store.GetIdentity(ctx, in.Provider, normalizeLogin(in.SenderLogin)); ok {
+        ctx = WithActor(ctx, id.UserID)
+    }
+
+    pr, exists := s.store.GetPullRequestByID(ctx, in.Repository, in.ID)
+    if in.Action == IngestOpened {
+        if exists {
+            return pr, nil
+        }
+        return s.ingestOpened(ctx, in)
+    }
+    if !exists {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+
+    switch in.Action {
+    case IngestReady:
+        if pr.Status != domain.StatusDraft {
+            return pr, nil
+        }
+        res, err := s.MarkPullRequestReady(ctx, MarkReadyInput{Repository: in.Repository, ID: in.ID})
+        if err != nil {
+            return nil, err
+        }
+        return res.PR, nil
+    case IngestClosed:
+        if pr.Status == domain.StatusClosed {
+            return pr, nil
+        }
+        return s.ClosePullRequest(ctx, in.Repository, in.ID)
+    case IngestMerged:
+        return s.ingestMerged(ctx, pr)
+    case IngestReopened:
+        if pr.Status == domain.StatusOpen {
+            return pr, nil
+        }
+        return s.ReopenPullRequest(ctx, in.Repository, in.ID)
+    default:
+        return nil, NewAppError(ErrorCodeBadRequest, "unsupported action "+string(in.Action))
+    }
+}
+
+func (s *Service) ingestOpened(ctx context.Context, in IngestPullRequestInput) (*domain.PullRequest, error) {
+    author, ok := s.store.GetIdentity(ctx, in.Provider, normalizeLogin(in.AuthorLogin))
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "no user mapped to "+string(in.Provider)+" login "+in.AuthorLogin)
+    }
+
+    res, err := s.CreatePullRequest(ctx, CreatePullRequestInput{
+        Repository:   in.Repository,
+        ID:           in.ID,
+        Name:         in.Name,
+        AuthorID:     author.UserID,
+        Draft:        in.Draft,
+        Description:  in.Description,
+        Labels:       in.Labels,
+        SourceBranch: in.SourceBranch,
+        TargetBranch: in.TargetBranch,
+        ExternalURL:  in.ExternalURL,
+    })
+    if err != nil {
+        return nil, err
+    }
+    return res.PR, nil
+}
+
+// ingestMerged records a merge that already happened on the code host, so
+// unlike MergePullRequest it does not enforce the team's approval rules.
+func (s *Service) ingestMerged(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+    if pr.Status == domain.StatusMerged {
+        return pr, nil
+    }
+    if err := checkTransition(pr, domain.StatusMerged); err != nil {
+        return nil, err
+    }
+    now := s.now()
+    pr.Status = domain.StatusMerged
+    pr.MergedAt = &now
+    s.updatePullRequest(ctx, pr, "")
+    return pr, nil
+}
+
+func normalizeLogin(login string) string {
+    return strings.ToLower(strings.TrimSpace(login))
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "testing"
+)
+
+func newIngestService(t *testing.T) *Service {
+    t.Helper()
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{RequiredApprovals: intPtr(2)})
+    if _, err := s.CreateRepository(ctx, "org/api", []string{"core"}); err != nil {
+        t.Fatalf("CreateRepository: %v", err)
+    }
+    for login, userID := range map[string]string{"Alice": "a", "bob": "b"} {
+        if _, err := s.SetIdentity(ctx, domain.ProviderGitHub, login, userID); err != nil {
+            t.Fatalf("SetIdentity: %v", err)
+        }
+    }
+    return s
+}
+
+func TestSetIdentityValidation(t *testing.T) {
+    ctx := context.Background()
+    s := newIngestService(t)
+
+    tests := []struct {
+        name     string
+        provider domain.Provider
+        login    string
+        userID   string
+        code     ErrorCode
+    }{
+        {"unknown provider", "bitbucket", "alice", "a", ErrorCodeBadRequest},
+        {"blank login", domain.ProviderGitHub, "  ", "a", ErrorCodeBadRequest},
+        {"unknown user", domain.ProviderGitHub, "alice", "nobody", ErrorCodeNotFound},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.SetIdentity(ctx, tt.provider, tt.login, tt.userID); !isAppError(err, tt.code) {
+                t.Fatalf("got %v, want %s", err, tt.code)
+            }
+        })
+    }
+
+    if err := s.DeleteIdentity(ctx, domain.ProviderGitHub, "ALICE"); err != nil {
+        t.Fatalf("DeleteIdentity is case-insensitive: %v", err)
+    }
+    if err := s.DeleteIdentity(ctx, domain.ProviderGitHub, "alice"); !isAppError(err, ErrorCodeNotFound) {
+        t.Fatalf("second DeleteIdentity: got %v, want NOT_FOUND", err)
+    }
+}
+
+func TestIngestPullRequestLifecycle(t *testing.T) {
+    ctx := context.Background()
+    s := newIngestService(t)
+    opened := IngestPullRequestInput{
+        Provider:    domain.ProviderGitHub,
+        Action:      IngestOpened,
+        Repository:  "org/api",
+        ID:          "7",
+        Name:        "Add search",
+        AuthorLogin: "alice",
+        SenderLogin: "alice",
+        Draft:       true,
+    }
+
+    pr, err := s.IngestPullRequest(ctx, opened)
+    if err != nil {
+        t.Fatalf("opened: %v", err)
+    }
+    if pr.AuthorID != "a" || pr.Status != domain.StatusDraft {
+        t.Fatalf("opened PR = %+v, want a draft by a", pr)
+    }
+
+    // Redelivered events return the PR unchanged.
+    again, err := s.IngestPullRequest(ctx, opened)
+    if err != nil || again.Status != domain.StatusDraft {
+        t.Fatalf("redelivered opened: got %+v, %v", again, err)
+    }
+
+    for _, step := range []struct {
+        action IngestAction
+        want   domain.PRStatus
+    }{
+        {IngestReady, domain.StatusOpen},
+        {IngestReady, domain.StatusOpen},
+        {IngestClosed, domain.StatusClosed},
+        {IngestClosed, domain.StatusClosed},
+        {IngestReopened, domain.StatusOpen},
+        // The code host already merged it, so missing approvals do not block.
+        {IngestMerged, domain.StatusMerged},
+        {IngestMerged, domain.StatusMerged},
+    } {
+        in := opened
+        in.Action = step.action
+        in.SenderLogin = "bob"
+        pr, err := s.IngestPullRequest(ctx, in)
+        if err != nil {
+            t.Fatalf("%s: %v", step.action, err)
+        }
+        if pr.Status != step.want {
+            t.Fatalf("%s: status %s, want %s", step.action, pr.Status, step.want)
+        }
+    }
+
+    events, err := s.PullRequestHistory(ctx, "org/api", "7")
+    if err != nil {
+        t.Fatalf("PullRequestHistory: %v", err)
+    }
+    last := events[len(events)-1]
+    if last.Type != domain.EventMerged || last.ActorID != "b" {
+        t.Fatalf("last event = %+v, want a merge by the sender b", *last)
+    }
+}
+
+func TestIngestPullRequestErrors(t *testing.T) {
+    ctx := context.Background()
+    s := newIngestService(t)
+
+    tests := []struct {
+        name string
+        in   IngestPullRequestInput
+        code ErrorCode
+    }{
+        {"no number", IngestPullRequestInput{Action: IngestOpened, Repository: "org/api"}, ErrorCodeBadRequest},
+        {"unregistered repository", IngestPullRequestInput{Action: IngestOpened, Repository: "org/web", ID: "1", AuthorLogin: "alice"}, ErrorCodeNotFound},
+        {"unmapped author", IngestPullRequestInput{Provider: domain.ProviderGitHub, Action: IngestOpened, Repository: "org/api", ID: "1", AuthorLogin: "carol"}, ErrorCodeNotFound},
+        {"author mapped on another provider", IngestPullRequestInput{Provider: domain.ProviderGitLab, Action: IngestOpened, Repository: "org/api", ID: "1", AuthorLogin: "alice"}, ErrorCodeNotFound},
+        {"unknown pull request", IngestPullRequestInput{Provider: domain.ProviderGitHub, Action: IngestClosed, Repository: "org/api", ID: "1"}, ErrorCodeNotFound},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.IngestPullRequest(ctx, tt.in); !isAppError(err, tt.code) {
+                t.Fatalf("got %v, want %s", err, tt.code)
+            }
+        })
+    }
+}
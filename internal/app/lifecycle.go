@@ -0,0 +1,98 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+// MarkPullRequestReady moves a DRAFT PR to OPEN and assigns its reviewers
+// the way CreatePullRequest does for non-draft PRs.
+func (s *Service) MarkPullRequestReady(ctx context.Context, in MarkReadyInput) (*CreatePullRequestResult, error) {
+    pr, ok := s.store.GetPullRequestByID(ctx, in.Repository, in.ID)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := checkTransition(pr, domain.StatusOpen); err != nil {
+        return nil, err
+    }
+    if pr.Status != domain.StatusDraft {
+        return nil, NewAppError(ErrorCodeInvalidTransition, "only DRAFT PRs can be marked ready")
+    }
+
+    pr.Status = domain.StatusOpen
+    res, err := s.assignReviewers(ctx, pr, s.autoExclusions(ctx, pr), in.ChangedFiles, in.RequiredSkills)
+    if err != nil {
+        return nil, err
+    }
+
+    s.updatePullRequest(ctx, pr, reasonReady)
+    return res, nil
+}
+
+// ClosePullRequest abandons a DRAFT or OPEN PR without merging it.
+// Reviewers stay on the PR but no longer count towards open load.
+func (s *Service) ClosePullRequest(ctx context.Context, repo, id string) (*domain.PullRequest, error) {
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, id)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := checkTransition(pr, domain.StatusClosed); err != nil {
+        return nil, err
+    }
+
+    pr.Status = domain.StatusClosed
+    s.updatePullRequest(ctx, pr, "")
+    return pr, nil
+}
+
+// ReopenPullRequest moves a CLOSED PR back to OPEN. Reviewers who became
+// inactive meanwhile are dropped and missing slots are filled again.
+func (s *Service) ReopenPullRequest(ctx context.Context, repo, id string) (*domain.PullRequest, error) {
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, id)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := checkTransition(pr, domain.StatusOpen); err != nil {
+        return nil, err
+    }
+    if pr.Status != domain.StatusClosed {
+        return nil, NewAppError(ErrorCodeInvalidTransition, "only CLOSED PRs can be reopened")
+    }
+
+    active := make([]string, 0, len(pr.AssignedReviewers))
+    for _, id := range pr.AssignedReviewers {
+        if u, ok := s.store.GetUserByID(ctx, id); ok && u.IsActive {
+            active = append(active, id)
+        }
+    }
+    pr.AssignedReviewers = active
+    pr.Status = domain.StatusOpen
+
+    // A reopened PR is not blocked when everyone is at capacity.
+    if _, err := s.assignReviewers(ctx, pr, s.autoExclusions(ctx, pr), nil, nil); err != nil && !isAppError(err, ErrorCodeAllAtCapacity) {
+        return nil, err
+    }
+
+    s.updatePullRequest(ctx, pr, reasonReopened)
+    return pr, nil
+}
+
+func checkTransition(pr *domain.PullRequest, next domain.PRStatus) error {
+    if pr.Status.CanTransitionTo(next) {
+        return nil
+    }
+    return NewAppError(ErrorCodeInvalidTransition,
+        "cannot move PR from "+string(pr.Status)+" to "+string(next))
+}
+
+// requireOpen rejects reviewer changes on PRs that are not OPEN.
+func requireOpen(pr *domain.PullRequest, action string) error {
+    switch pr.Status {
+    case domain.StatusOpen:
+        return nil
+    case domain.StatusMerged:
+        return NewAppError(ErrorCodePRMerged, "cannot "+action+" on merged PR")
+    default:
+        return NewAppError(ErrorCodePRNotOpen, "cannot "+action+" on "+string(pr.Status)+" PR")
+    }
+}
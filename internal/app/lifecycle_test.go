@@ -0,0 +1,134 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestDraftPullRequestLifecycle(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a", Draft: true})
+    if pr.Status != domain.StatusDraft || len(pr.AssignedReviewers) != 0 {
+        t.Fatalf("draft PR %+v, want DRAFT without reviewers", pr)
+    }
+    if _, err := s.AddReviewer(ctx, "", "p", "b"); !isAppError(err, ErrorCodePRNotOpen) {
+        t.Fatalf("AddReviewer on draft: got %v, want PR_NOT_OPEN", err)
+    }
+    if _, err := s.MergePullRequest(ctx, "", "p"); !isAppError(err, ErrorCodeInvalidTransition) {
+        t.Fatalf("merging a draft: got %v, want INVALID_STATUS_TRANSITION", err)
+    }
+
+    res, err := s.MarkPullRequestReady(ctx, MarkReadyInput{ID: "p"})
+    if err != nil {
+        t.Fatalf("MarkPullRequestReady: %v", err)
+    }
+    if res.PR.Status != domain.StatusOpen || len(res.PR.AssignedReviewers) != 2 {
+        t.Fatalf("ready PR %+v, want OPEN with two reviewers", res.PR)
+    }
+    if _, err := s.MarkPullRequestReady(ctx, MarkReadyInput{ID: "p"}); !isAppError(err, ErrorCodeInvalidTransition) {
+        t.Fatalf("marking an OPEN PR ready: got %v, want INVALID_STATUS_TRANSITION", err)
+    }
+}
+
+func TestCloseAndReopenPullRequest(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+    first := pr.AssignedReviewers[0]
+
+    if _, err := s.ReopenPullRequest(ctx, "", "p"); !isAppError(err, ErrorCodeInvalidTransition) {
+        t.Fatalf("reopening an OPEN PR: got %v, want INVALID_STATUS_TRANSITION", err)
+    }
+
+    closed, err := s.ClosePullRequest(ctx, "", "p")
+    if err != nil {
+        t.Fatalf("ClosePullRequest: %v", err)
+    }
+    if closed.Status != domain.StatusClosed || !reflect.DeepEqual(closed.AssignedReviewers, []string{first}) {
+        t.Fatalf("closed PR %+v, want CLOSED keeping its reviewer", closed)
+    }
+    if _, err := s.MergePullRequest(ctx, "", "p"); !isAppError(err, ErrorCodeInvalidTransition) {
+        t.Fatalf("merging a closed PR: got %v, want INVALID_STATUS_TRANSITION", err)
+    }
+    if _, err := s.RemoveReviewer(ctx, "", "p", first); !isAppError(err, ErrorCodePRNotOpen) {
+        t.Fatalf("RemoveReviewer on closed: got %v, want PR_NOT_OPEN", err)
+    }
+
+    if _, err := s.SetUserIsActive(ctx, first, false, false); err != nil {
+        t.Fatalf("SetUserIsActive: %v", err)
+    }
+    reopened, err := s.ReopenPullRequest(ctx, "", "p")
+    if err != nil {
+        t.Fatalf("ReopenPullRequest: %v", err)
+    }
+    if reopened.Status != domain.StatusOpen || len(reopened.AssignedReviewers) != 1 || reopened.AssignedReviewers[0] == first {
+        t.Fatalf("reopened PR %+v, want OPEN with %s replaced", reopened, first)
+    }
+}
+
+func TestMergedPullRequestIsFinal(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+
+    merged, err := s.MergePullRequest(ctx, "", "p")
+    if err != nil {
+        t.Fatalf("MergePullRequest: %v", err)
+    }
+    again, err := s.MergePullRequest(ctx, "", "p")
+    if err != nil || !again.MergedAt.Equal(*merged.MergedAt) {
+        t.Fatalf("second merge: got %+v, %v; want the same merged PR", again, err)
+    }
+
+    if _, err := s.ClosePullRequest(ctx, "", "p"); !isAppError(err, ErrorCodeInvalidTransition) {
+        t.Fatalf("closing a merged PR: got %v, want INVALID_STATUS_TRANSITION", err)
+    }
+    if _, err := s.ReassignReviewer(ctx, "", "p", "b", ""); !isAppError(err, ErrorCodePRMerged) {
+        t.Fatalf("reassigning on a merged PR: got %v, want PR_MERGED", err)
+    }
+}
+
+func TestCreatePullRequestMetadata(t *testing.T) {
+    ctx := context.Background()
+    clock := newFakeClock()
+    s := newTestService(t, WithClock(clock.Now))
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+
+    _, err := s.CreatePullRequest(ctx, CreatePullRequestInput{ID: "p0", Name: "p0", AuthorID: "a", ExternalURL: "ftp://example.com/pr/1"})
+    if !isAppError(err, ErrorCodeBadRequest) {
+        t.Fatalf("non-http external_url: got %v, want BAD_REQUEST", err)
+    }
+
+    pr := mustCreatePR(t, s, CreatePullRequestInput{
+        ID:           "p1",
+        AuthorID:     "a",
+        Labels:       []string{" bug ", "", "bug", "ui"},
+        SourceBranch: "feature",
+        TargetBranch: "main",
+        ExternalURL:  "https://example.com/pr/1",
+    })
+    if !reflect.DeepEqual(pr.Labels, []string{"bug", "ui"}) {
+        t.Fatalf("labels %q, want [bug ui]", pr.Labels)
+    }
+    if !pr.CreatedAt.Equal(clock.Now()) || !pr.UpdatedAt.Equal(clock.Now()) {
+        t.Fatalf("created %v, updated %v; want both %v", pr.CreatedAt, pr.UpdatedAt, clock.Now())
+    }
+
+    clock.Advance(time.Hour)
+    closed, err := s.ClosePullRequest(ctx, "", "p1")
+    if err != nil {
+        t.Fatalf("ClosePullRequest: %v", err)
+    }
+    if !closed.UpdatedAt.Equal(clock.Now()) || closed.CreatedAt.Equal(closed.UpdatedAt) {
+        t.Fatalf("after close created %v, updated %v; want only updatedAt moved", closed.CreatedAt, closed.UpdatedAt)
+    }
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "sort"
+)
+
+// RebalanceTeam moves reviewer slots on OPEN PRs the team reviews from
+// its most loaded members to the least loaded eligible ones, one slot at a
+// time, until no move narrows the gap between two members. Loads count only
+// the team's own PRs. Moves never go to the author, an excluded user or a
+// user at their open review limit, and never drop a PR below its home
+// team's role rule. With dryRun the moves are only planned.
+func (s *Service) RebalanceTeam(ctx context.Context, teamName string, dryRun bool) (*RebalanceResult, error) {
+    if teamName == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "team_name is required")
+    }
+
+    members, ok := s.store.ListUsersByTeam(ctx, teamName)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "team not found")
+    }
+
+    // Open review limits apply to reviews across all teams.
+    open, err := s.store.CountOpenReviews(ctx, userIDs(members))
+    if err != nil {
+        return nil, err
+    }
+
+    loads := make(map[string]int, len(members))
+    for _, u := range members {
+        loads[u.ID] = 0
+    }
+
+    prs := s.store.ListOpenPullRequestsByTeam(ctx, teamName)
+    exclude := make(map[*domain.PullRequest]map[string]struct{}, len(prs))
+    rules := make(map[*domain.PullRequest]roleRule, len(prs))
+    for _, pr := range prs {
+        exclude[pr] = s.autoExclusions(ctx, pr)
+        for _, id := range pr.AssignedReviewers {
+            if _, ok := loads[id]; ok {
+                loads[id]++
+            }
+        }
+        home, _, err := s.reviewScope(ctx, pr)
+        if err != nil {
+            return nil, err
+        }
+        settings := s.teamSettings(ctx, home)
+        rules[pr] = roleRule{role: settings.MinReviewerRole, min: settings.MinRoleReviewers}
+    }
+
+    f := &slotFiller{s: s, limits: make(map[string]int)}
+    targets := s.filterEligible(ctx, members, nil)
+    sources := append([]*domain.User(nil), members...)
+
+    res := &RebalanceResult{TeamName: teamName, DryRun: dryRun, Moves: []ReviewerMove{}}
+    changed := make(map[*domain.PullRequest]bool)
+    for {
+        sortByLoad(sources, loads, true)
+        sortByLoad(targets, loads, false)
+
+        move, pr, found := f.nextRebalanceMove(ctx, sources, targets, loads, open, prs, exclude, rules)
+        if !found {
+            break
+        }
+
+        pr.AssignedReviewers[indexOf(pr.AssignedReviewers, move.FromUserID)] = move.ToUserID
+        exclude[pr][move.ToUserID] = struct{}{}
+        loads[move.FromUserID]--
+        loads[move.ToUserID]++
+        open[move.FromUserID]--
+        open[move.ToUserID]++
+        changed[pr] = true
+        res.Moves = append(res.Moves, move)
+    }
+
+    if !dryRun {
+        for _, pr := range prs {
+            if changed[pr] {
+                s.updatePullRequest(ctx, pr, reasonRebalance)
+            }
+        }
+    }
+
+    return res, nil
+}
+
+func (f *slotFiller) nextRebalanceMove(
+    ctx context.Context,
+    sources, targets []*domain.User,
+    loads, open map[string]int,
+    prs []*domain.PullRequest,
+    exclude map[*domain.PullRequest]map[string]struct{},
+    rules map[*domain.PullRequest]roleRule,
+) (ReviewerMove, *domain.PullRequest, bool) {
+    for _, from := range sources {
+        for _, to := range targets {
+            if loads[from.ID]-loads[to.ID] < 2 {
+                break
+            }
+            if limit := f.limit(ctx, to); limit > 0 && open[to.ID] >= limit {
+                continue
+            }
+
+            for _, pr := range prs {
+                if !containsString(pr.AssignedReviewers, from.ID) {
+                    continue
+                }
+                if _, skip := exclude[pr][to.ID]; skip {
+                    continue
+                }
+                if !f.keepsRoleRule(ctx, pr, from, to, rules[pr]) {
+                    continue
+                }
+                return ReviewerMove{
+                    Repository:    pr.Repository,
+                    PullRequestID: pr.ID,
+                    FromUserID:    from.ID,
+                    ToUserID:      to.ID,
+                }, pr, true
+            }
+        }
+    }
+    return ReviewerMove{}, nil, false
+}
+
+// roleRule is a home team's MinRoleReviewers requirement.
+type roleRule struct {
+    role domain.Role
+    min  int
+}
+
+// keepsRoleRule reports whether replacing from with to on pr still leaves
+// it with the reviewers of the required role its home team asks for.
+func (f *slotFiller) keepsRoleRule(ctx context.Context, pr *domain.PullRequest, from, to *domain.User, rule roleRule) bool {
+    if rule.min <= 0 || !from.Role.AtLeast(rule.role) || to.Role.AtLeast(rule.role) {
+        return true
+    }
+    return f.countWithRole(ctx, rule.role, pr.AssignedReviewers)-1 >= rule.min
+}
+
+// sortByLoad orders users by open review load, breaking ties by ID.
+func sortByLoad(users []*domain.User, loads map[string]int, desc bool) {
+    sort.Slice(users, func(i, j int) bool {
+        li, lj := loads[users[i].ID], loads[users[j].ID]
+        if li != lj {
+            return (li > lj) == desc
+        }
+        return users[i].ID < users[j].ID
+    })
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "strconv"
+    "testing"
+)
+
+func TestRebalanceTeamKeepsRoleRule(t *testing.T) {
+    tests := []struct {
+        name      string
+        newRole   domain.Role
+        wantMoves int
+    }{
+        {name: "junior cannot take a senior slot", newRole: domain.RoleJunior, wantMoves: 0},
+        {name: "senior can take a senior slot", newRole: domain.RoleSenior, wantMoves: 1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx := context.Background()
+            s := newTestService(t)
+            mustCreateTeam(t, s, "core", member("a"), memberAs("s", domain.RoleSenior))
+            mustUpdateSettings(t, s, "core", TeamSettingsInput{
+                ReviewersRequired: intPtr(1),
+                MinRoleReviewers:  intPtr(1),
+            })
+            for i := 0; i < 3; i++ {
+                mustCreatePR(t, s, CreatePullRequestInput{ID: "p" + strconv.Itoa(i), AuthorID: "a"})
+            }
+            if _, err := s.AddTeamMember(ctx, "core", memberAs("n", tt.newRole)); err != nil {
+                t.Fatalf("AddTeamMember: %v", err)
+            }
+
+            res, err := s.RebalanceTeam(ctx, "core", false)
+            if err != nil {
+                t.Fatalf("RebalanceTeam: %v", err)
+            }
+            if len(res.Moves) != tt.wantMoves {
+                t.Fatalf("got %d moves %+v, want %d", len(res.Moves), res.Moves, tt.wantMoves)
+            }
+
+            for i := 0; i < 3; i++ {
+                pr := mustGetPR(t, s, "p"+strconv.Itoa(i))
+                seniors := 0
+                for _, id := range pr.AssignedReviewers {
+                    if u, _ := s.store.GetUserByID(ctx, id); u.Role.AtLeast(domain.RoleSenior) {
+                        seniors++
+                    }
+                }
+                if seniors < 1 {
+                    t.Errorf("%s has reviewers %v, none of them senior", pr.ID, pr.AssignedReviewers)
+                }
+            }
+        })
+    }
+}
+
+func TestRebalanceTeamCountsOnlyTeamLoad(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustCreateTeam(t, s, "other", member("x"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1), MinRoleReviewers: intPtr(0)})
+    mustUpdateSettings(t, s, "other", TeamSettingsInput{
+        ReviewersRequired: intPtr(1),
+        MinRoleReviewers:  intPtr(0),
+        FallbackTeams:     &[]string{"core"},
+    })
+
+    // b and c review two core PRs each; c also reviews other's PRs, which
+    // must not make c look overloaded within core.
+    for i := 0; i < 4; i++ {
+        pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "x" + strconv.Itoa(i), AuthorID: "x"})
+        if pr.AssignedReviewers[0] != "c" {
+            if _, err := s.ReassignReviewer(ctx, "", pr.ID, pr.AssignedReviewers[0], "c"); err != nil {
+                t.Fatalf("ReassignReviewer: %v", err)
+            }
+        }
+    }
+    for i := 0; i < 4; i++ {
+        pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p" + strconv.Itoa(i), AuthorID: "a"})
+        want := []string{"b", "c"}[i%2]
+        if pr.AssignedReviewers[0] != want {
+            if _, err := s.ReassignReviewer(ctx, "", pr.ID, pr.AssignedReviewers[0], want); err != nil {
+                t.Fatalf("ReassignReviewer: %v", err)
+            }
+        }
+    }
+
+    res, err := s.RebalanceTeam(ctx, "core", true)
+    if err != nil {
+        t.Fatalf("RebalanceTeam: %v", err)
+    }
+    if len(res.Moves) != 0 {
+        t.Fatalf("got moves %+v, want none for an even team load", res.Moves)
+    }
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *Service) CreateRepository(ctx context.Context, name string, ownerTeams []string) (*domain.Repository, error) {
+    if name == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "repository name is required")
+    }
+    owners, err := s.checkOwnerTeams(ctx, ownerTeams)
+    if err != nil {
+        return nil, err
+    }
+
+    repo := &domain.Repository{Name: name, OwnerTeams: owners}
+    if !s.store.CreateRepository(ctx, repo) {
+        return nil, NewAppError(ErrorCodeRepositoryExists, "repository already exists")
+    }
+    return repo, nil
+}
+
+func (s *Service) GetRepository(ctx context.Context, name string) (*domain.Repository, error) {
+    repo, ok := s.store.GetRepository(ctx, name)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "repository not found")
+    }
+    return repo, nil
+}
+
+func (s *Service) ListRepositories(ctx context.Context) []*domain.Repository {
+    return s.store.ListRepositories(ctx)
+}
+
+// SetRepositoryOwners replaces the repository's owner teams. Reviewers
+// already assigned to its PRs stay; only later assignments use the new owners.
+func (s *Service) SetRepositoryOwners(ctx context.Context, name string, ownerTeams []string) (*domain.Repository, error) {
+    repo, ok := s.store.GetRepository(ctx, name)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "repository not found")
+    }
+    owners, err := s.checkOwnerTeams(ctx, ownerTeams)
+    if err != nil {
+        return nil, err
+    }
+
+    repo.OwnerTeams = owners
+    if !s.store.UpdateRepository(ctx, repo) {
+        return nil, NewAppError(ErrorCodeNotFound, "repository not found")
+    }
+    return repo, nil
+}
+
+func (s *Service) checkOwnerTeams(ctx context.Context, ownerTeams []string) ([]string, error) {
+    if len(ownerTeams) == 0 {
+        return nil, NewAppError(ErrorCodeBadRequest, "at least one owner team is required")
+    }
+    owners := make([]string, 0, len(ownerTeams))
+    for _, name := range ownerTeams {
+        if containsString(owners, name) {
+            return nil, NewAppError(ErrorCodeBadRequest, "duplicate owner team "+name)
+        }
+        if !s.store.TeamExists(ctx, name) {
+            return nil, NewAppError(ErrorCodeNotFound, "owner team "+name+" not found")
+        }
+        owners = append(owners, name)
+    }
+    return owners, nil
+}
+
+// reviewScope returns the team whose settings govern pr's review and the
+// teams reviewers are drawn from before that team's fallbacks. PRs of a
+// repository are reviewed by its owner teams, the first one being the home
+// team; other PRs by the author's team.
+func (s *Service) reviewScope(ctx context.Context, pr *domain.PullRequest) (string, []string, error) {
+    if pr.Repository != domain.DefaultRepository {
+        repo, ok := s.store.GetRepository(ctx, pr.Repository)
+        if !ok || len(repo.OwnerTeams) == 0 {
+            return "", nil, NewAppError(ErrorCodeNotFound, "repository not found")
+        }
+        return repo.OwnerTeams[0], repo.OwnerTeams, nil
+    }
+
+    author, ok := s.store.GetUserByID(ctx, pr.AuthorID)
+    if !ok {
+        return "", nil, NewAppError(ErrorCodeNotFound, "author not found")
+    }
+    return author.TeamName, []string{author.TeamName}, nil
+}
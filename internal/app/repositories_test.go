@@ -0,0 +1,75 @@
+package app
+
+import (
+    "context"
+    "reflect"
+    "testing"
+)
+
+func TestCreateRepositoryValidation(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"))
+
+    tests := []struct {
+        name   string
+        repo   string
+        owners []string
+        code   ErrorCode
+    }{
+        {"no owners", "api", nil, ErrorCodeBadRequest},
+        {"duplicate owner", "api", []string{"core", "core"}, ErrorCodeBadRequest},
+        {"unknown owner", "api", []string{"nobody"}, ErrorCodeNotFound},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.CreateRepository(ctx, tt.repo, tt.owners); !isAppError(err, tt.code) {
+                t.Fatalf("got %v, want %s", err, tt.code)
+            }
+        })
+    }
+
+    if _, err := s.CreateRepository(ctx, "api", []string{"core"}); err != nil {
+        t.Fatalf("CreateRepository: %v", err)
+    }
+    if _, err := s.CreateRepository(ctx, "api", []string{"core"}); !isAppError(err, ErrorCodeRepositoryExists) {
+        t.Fatalf("second CreateRepository: got %v, want REPOSITORY_EXISTS", err)
+    }
+}
+
+func TestRepositoryPullRequestsUseOwnerTeams(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustCreateTeam(t, s, "platform", member("x"), member("y"))
+    if _, err := s.CreateRepository(ctx, "infra", []string{"platform"}); err != nil {
+        t.Fatalf("CreateRepository: %v", err)
+    }
+
+    // The same ID lives independently in every repository.
+    local := mustCreatePR(t, s, CreatePullRequestInput{ID: "1", AuthorID: "a"})
+    infra := mustCreatePR(t, s, CreatePullRequestInput{Repository: "infra", ID: "1", AuthorID: "a"})
+    if !reflect.DeepEqual(local.AssignedReviewers, []string{"b"}) {
+        t.Fatalf("PR outside repositories got %v, want the author's team", local.AssignedReviewers)
+    }
+    if !reflect.DeepEqual(infra.AssignedReviewers, []string{"x", "y"}) {
+        t.Fatalf("infra PR got %v, want the owner team", infra.AssignedReviewers)
+    }
+
+    _, err := s.CreatePullRequest(ctx, CreatePullRequestInput{Repository: "unknown", ID: "1", Name: "1", AuthorID: "a"})
+    if !isAppError(err, ErrorCodeNotFound) {
+        t.Fatalf("unknown repository: got %v, want NOT_FOUND", err)
+    }
+
+    if _, err := s.SetRepositoryOwners(ctx, "infra", []string{"core"}); err != nil {
+        t.Fatalf("SetRepositoryOwners: %v", err)
+    }
+    existing, _ := s.store.GetPullRequestByID(ctx, "infra", "1")
+    if !reflect.DeepEqual(existing.AssignedReviewers, []string{"x", "y"}) {
+        t.Fatalf("existing reviewers changed to %v", existing.AssignedReviewers)
+    }
+    pr := mustCreatePR(t, s, CreatePullRequestInput{Repository: "infra", ID: "2", AuthorID: "x"})
+    if !reflect.DeepEqual(pr.AssignedReviewers, []string{"a", "b"}) {
+        t.Fatalf("PR after owner change got %v, want the new owners", pr.AssignedReviewers)
+    }
+}
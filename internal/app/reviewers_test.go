@@ -0,0 +1,159 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "testing"
+)
+
+func TestSelfAssignReviewer(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", memberAs("a", domain.RoleSenior), memberAs("b", domain.RoleSenior), memberAs("c", domain.RoleSenior))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+    volunteer := "b"
+    if pr.AssignedReviewers[0] == "b" {
+        volunteer = "c"
+    }
+
+    if _, err := s.SelfAssignReviewer(ctx, "", "p"); !isAppError(err, ErrorCodeBadRequest) {
+        t.Fatalf("without an actor got %v, want BAD_REQUEST", err)
+    }
+
+    got, err := s.SelfAssignReviewer(WithActor(ctx, volunteer), "", "p")
+    if err != nil {
+        t.Fatalf("SelfAssignReviewer: %v", err)
+    }
+    if !containsString(got.AssignedReviewers, volunteer) {
+        t.Fatalf("reviewers %v do not include %s", got.AssignedReviewers, volunteer)
+    }
+
+    events := s.store.ListPREvents(ctx, "", "p")
+    last := events[len(events)-1]
+    if last.Type != domain.EventReviewerAssigned || last.NewReviewerID != volunteer || last.Reason != reasonSelfAssign {
+        t.Fatalf("last event %+v, want %s self-assigned", last, volunteer)
+    }
+}
+
+func TestAddReviewerIsManualEvenForActor(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", memberAs("a", domain.RoleSenior), memberAs("b", domain.RoleSenior), memberAs("c", domain.RoleSenior))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+    other := "b"
+    if pr.AssignedReviewers[0] == "b" {
+        other = "c"
+    }
+
+    if _, err := s.AddReviewer(WithActor(ctx, other), "", "p", other); err != nil {
+        t.Fatalf("AddReviewer: %v", err)
+    }
+    events := s.store.ListPREvents(ctx, "", "p")
+    if reason := events[len(events)-1].Reason; reason != reasonManual {
+        t.Fatalf("reason %q, want %q", reason, reasonManual)
+    }
+}
+
+func TestManualReviewerChanges(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+    mustCreateTeam(t, s, "other", member("x"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a", ExcludedReviewerIDs: []string{"d"}})
+    assigned := pr.AssignedReviewers[0]
+
+    tests := []struct {
+        name   string
+        userID string
+        code   ErrorCode
+    }{
+        {"already assigned", assigned, ErrorCodeAlreadyAssigned},
+        {"author", "a", ErrorCodeReviewerNotEligible},
+        {"excluded", "d", ErrorCodeReviewerNotEligible},
+        {"unknown", "nobody", ErrorCodeNotFound},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.AddReviewer(ctx, "", "p", tt.userID); !isAppError(err, tt.code) {
+                t.Fatalf("AddReviewer(%s): got %v, want %s", tt.userID, err, tt.code)
+            }
+        })
+    }
+
+    // A manual pick may come from any team.
+    if _, err := s.AddReviewer(ctx, "", "p", "x"); err != nil {
+        t.Fatalf("AddReviewer(x): %v", err)
+    }
+
+    res, err := s.ReassignReviewer(ctx, "", "p", "x", "d")
+    if !isAppError(err, ErrorCodeReviewerNotEligible) {
+        t.Fatalf("targeted reassign to excluded d: got %+v, %v", res, err)
+    }
+    target := "b"
+    if assigned == "b" {
+        target = "c"
+    }
+    res, err = s.ReassignReviewer(ctx, "", "p", "x", target)
+    if err != nil {
+        t.Fatalf("ReassignReviewer: %v", err)
+    }
+    if res.ReplacedBy != target || !containsString(res.PR.AssignedReviewers, target) || containsString(res.PR.AssignedReviewers, "x") {
+        t.Fatalf("got %+v, want x replaced by %s", res, target)
+    }
+
+    got, err := s.RemoveReviewer(ctx, "", "p", target)
+    if err != nil {
+        t.Fatalf("RemoveReviewer: %v", err)
+    }
+    if len(got.AssignedReviewers) != 1 || got.AssignedReviewers[0] != assigned {
+        t.Fatalf("reviewers %v after removal, want [%s]", got.AssignedReviewers, assigned)
+    }
+    if _, err := s.RemoveReviewer(ctx, "", "p", target); !isAppError(err, ErrorCodeNotAssigned) {
+        t.Fatalf("removing twice: got %v, want NOT_ASSIGNED", err)
+    }
+}
+
+func TestDeclineReview(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+    first := pr.AssignedReviewers[0]
+    second := "b"
+    if first == "b" {
+        second = "c"
+    }
+
+    if _, err := s.DeclineReview(ctx, "", "p", first, ""); !isAppError(err, ErrorCodeBadRequest) {
+        t.Fatalf("without a reason got %v, want BAD_REQUEST", err)
+    }
+
+    res, err := s.DeclineReview(ctx, "", "p", first, " No Context ")
+    if err != nil {
+        t.Fatalf("DeclineReview: %v", err)
+    }
+    if res.ReplacedBy != second || len(res.PR.AssignedReviewers) != 1 || res.PR.AssignedReviewers[0] != second {
+        t.Fatalf("got %+v, want %s to take over", res, second)
+    }
+
+    // Nobody is left to take over: the decline fails, the reviewer stays
+    // and no reason is recorded.
+    if _, err := s.DeclineReview(ctx, "", "p", second, "busy"); !isAppError(err, ErrorCodeNoCandidate) {
+        t.Fatalf("without candidates got %v, want NO_CANDIDATE", err)
+    }
+    if got := mustGetPR(t, s, "p"); len(got.AssignedReviewers) != 1 || got.AssignedReviewers[0] != second {
+        t.Fatalf("reviewers %v, want %s kept", got.AssignedReviewers, second)
+    }
+
+    stats, err := s.GetStats(ctx)
+    if err != nil {
+        t.Fatalf("GetStats: %v", err)
+    }
+    if stats.DeclineReasons["no context"] != 1 || stats.DeclineReasons["busy"] != 0 {
+        t.Fatalf("decline reasons %v", stats.DeclineReasons)
+    }
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "strconv"
+    "strings"
+)
+
+// SubmitReview stores a reviewer's decision on an OPEN PR they are
+// assigned to, replacing any decision they submitted before.
+func (s *Service) SubmitReview(ctx context.Context, in SubmitReviewInput) (*domain.Review, error) {
+    if in.PullRequestID == "" || in.ReviewerID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "pull_request_id and reviewer_id are required")
+    }
+    decision := domain.ReviewDecision(strings.ToUpper(strings.TrimSpace(string(in.Decision))))
+    if !decision.Valid() {
+        return nil, NewAppError(ErrorCodeBadRequest, "decision must be APPROVED, CHANGES_REQUESTED or COMMENTED")
+    }
+
+    pr, ok := s.store.GetPullRequestByID(ctx, in.Repository, in.PullRequestID)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := requireOpen(pr, "submit review"); err != nil {
+        return nil, err
+    }
+    if !containsString(pr.AssignedReviewers, in.ReviewerID) {
+        return nil, NewAppError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+    }
+
+    review := &domain.Review{
+        Repository:    pr.Repository,
+        PullRequestID: pr.ID,
+        ReviewerID:    in.ReviewerID,
+        Decision:      decision,
+        Comment:       in.Comment,
+        SubmittedAt:   s.now(),
+    }
+    s.store.SaveReview(ctx, review)
+    return review, nil
+}
+
+func (s *Service) ListReviews(ctx context.Context, repo, prID string) ([]*domain.Review, error) {
+    if _, ok := s.store.GetPullRequestByID(ctx, repo, prID); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    return s.store.ListReviews(ctx, repo, prID), nil
+}
+
+// checkMergeable requires the home team's approval count from current
+// reviewers and no outstanding CHANGES_REQUESTED. Decisions of reviewers
+// no longer on the PR are ignored.
+func (s *Service) checkMergeable(ctx context.Context, pr *domain.PullRequest) error {
+    approvals := 0
+    for _, r := range s.store.ListReviews(ctx, pr.Repository, pr.ID) {
+        if !containsString(pr.AssignedReviewers, r.ReviewerID) {
+            continue
+        }
+        switch r.Decision {
+        case domain.DecisionChangesRequested:
+            return NewAppError(ErrorCodeMergeBlocked, "changes requested by "+r.ReviewerID)
+        case domain.DecisionApproved:
+            approvals++
+        }
+    }
+
+    required := 0
+    if teamName, _, err := s.reviewScope(ctx, pr); err == nil {
+        required = s.teamSettings(ctx, teamName).RequiredApprovals
+    }
+    if approvals < required {
+        return NewAppError(ErrorCodeMergeBlocked,
+            "PR has "+strconv.Itoa(approvals)+" of "+strconv.Itoa(required)+" required approvals")
+    }
+    return nil
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "testing"
+)
+
+func TestMergeGating(t *testing.T) {
+    type review struct {
+        reviewer string
+        decision domain.ReviewDecision
+    }
+    tests := []struct {
+        name     string
+        required int
+        reviews  []review
+        blocked  bool
+    }{
+        {"no rule", 0, nil, false},
+        {"missing approvals", 2, []review{{"b", domain.DecisionApproved}}, true},
+        {"enough approvals", 2, []review{{"b", domain.DecisionApproved}, {"c", domain.DecisionApproved}}, false},
+        {"comments do not count", 1, []review{{"b", domain.DecisionCommented}}, true},
+        {"changes requested", 0, []review{{"b", domain.DecisionChangesRequested}}, true},
+        {"changes requested despite approvals", 1, []review{{"b", domain.DecisionApproved}, {"c", domain.DecisionChangesRequested}}, true},
+        {"later decision replaces earlier", 1, []review{{"b", domain.DecisionChangesRequested}, {"b", domain.DecisionApproved}}, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx := context.Background()
+            s := newTestService(t)
+            mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+            mustUpdateSettings(t, s, "core", TeamSettingsInput{RequiredApprovals: intPtr(tt.required)})
+            mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+
+            for _, r := range tt.reviews {
+                _, err := s.SubmitReview(ctx, SubmitReviewInput{PullRequestID: "p", ReviewerID: r.reviewer, Decision: r.decision})
+                if err != nil {
+                    t.Fatalf("SubmitReview(%s): %v", r.reviewer, err)
+                }
+            }
+
+            _, err := s.MergePullRequest(ctx, "", "p")
+            if tt.blocked != isAppError(err, ErrorCodeMergeBlocked) {
+                t.Fatalf("MergePullRequest: got %v, blocked = %v", err, tt.blocked)
+            }
+            if !tt.blocked && err != nil {
+                t.Fatalf("MergePullRequest: %v", err)
+            }
+        })
+    }
+}
+
+func TestMergeIgnoresReviewsOfRemovedReviewers(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+
+    if _, err := s.SubmitReview(ctx, SubmitReviewInput{PullRequestID: "p", ReviewerID: "b", Decision: domain.DecisionChangesRequested}); err != nil {
+        t.Fatalf("SubmitReview: %v", err)
+    }
+    if _, err := s.RemoveReviewer(ctx, "", "p", "b"); err != nil {
+        t.Fatalf("RemoveReviewer: %v", err)
+    }
+    if _, err := s.MergePullRequest(ctx, "", "p"); err != nil {
+        t.Fatalf("MergePullRequest after removing the blocking reviewer: %v", err)
+    }
+}
+
+func TestSubmitReviewValidation(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+
+    tests := []struct {
+        name string
+        in   SubmitReviewInput
+        code ErrorCode
+    }{
+        {"unknown decision", SubmitReviewInput{PullRequestID: "p", ReviewerID: "b", Decision: "LGTM"}, ErrorCodeBadRequest},
+        {"not assigned", SubmitReviewInput{PullRequestID: "p", ReviewerID: "a", Decision: domain.DecisionApproved}, ErrorCodeNotAssigned},
+        {"unknown PR", SubmitReviewInput{PullRequestID: "x", ReviewerID: "b", Decision: domain.DecisionApproved}, ErrorCodeNotFound},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.SubmitReview(ctx, tt.in); !isAppError(err, tt.code) {
+                t.Fatalf("got %v, want %s", err, tt.code)
+            }
+        })
+    }
+
+    review, err := s.SubmitReview(ctx, SubmitReviewInput{PullRequestID: "p", ReviewerID: "b", Decision: " approved "})
+    if err != nil || review.Decision != domain.DecisionApproved {
+        t.Fatalf("lower-case decision: got %+v, %v", review, err)
+    }
+}
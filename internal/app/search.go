@@ -0,0 +1,110 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "strconv"
+    "time"
+)
+
+const (
+    defaultPageSize = 50
+    maxPageSize     = 100
+)
+
+// pageCursor is encoded into the opaque cursor handed to clients. It keeps
+// the order it was issued for so it cannot be replayed against another one.
+type pageCursor struct {
+    Sort       domain.PRSortField `json:"s"`
+    Desc       bool               `json:"d,omitempty"`
+    Value      time.Time          `json:"v"`
+    Repository string             `json:"r,omitempty"`
+    ID         string             `json:"i"`
+}
+
+// ListPullRequests returns one page of PRs matching in, ordered by in.Sort
+// (created_at by default).
+func (s *Service) ListPullRequests(ctx context.Context, in ListPullRequestsInput) (*PullRequestPage, error) {
+    q := domain.PullRequestQuery{
+        AuthorID:    in.AuthorID,
+        ReviewerID:  in.ReviewerID,
+        TeamName:    in.TeamName,
+        CreatedFrom: in.CreatedFrom,
+        CreatedTo:   in.CreatedTo,
+        Sort:        in.Sort,
+        Desc:        in.Desc,
+    }
+
+    for _, st := range in.Statuses {
+        if !st.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "unknown status "+string(st))
+        }
+        q.Statuses = append(q.Statuses, st)
+    }
+    if q.Sort == "" {
+        q.Sort = domain.SortByCreatedAt
+    }
+    if !q.Sort.Valid() {
+        return nil, NewAppError(ErrorCodeBadRequest, "unknown sort field "+string(q.Sort))
+    }
+    if !q.CreatedFrom.IsZero() && !q.CreatedTo.IsZero() && !q.CreatedFrom.Before(q.CreatedTo) {
+        return nil, NewAppError(ErrorCodeBadRequest, "created_from must be before created_to")
+    }
+
+    limit := in.Limit
+    switch {
+    case limit == 0:
+        limit = defaultPageSize
+    case limit < 0 || limit > maxPageSize:
+        return nil, NewAppError(ErrorCodeBadRequest, "limit must be between 1 and "+strconv.Itoa(maxPageSize))
+    }
+
+    if q.TeamName != "" && !s.store.TeamExists(ctx, q.TeamName) {
+        return nil, NewAppError(ErrorCodeNotFound, "team not found")
+    }
+
+    if in.Cursor != "" {
+        c, err := decodeCursor(in.Cursor)
+        if err != nil {
+            return nil, err
+        }
+        if c.Sort != q.Sort || c.Desc != q.Desc {
+            return nil, NewAppError(ErrorCodeBadRequest, "cursor was issued for a different sort order")
+        }
+        q.After = &domain.PRCursor{Value: c.Value, Repository: c.Repository, ID: c.ID}
+    }
+
+    // One extra row tells whether there is a next page.
+    q.Limit = limit + 1
+    prs := s.store.SearchPullRequests(ctx, q)
+
+    page := &PullRequestPage{PRs: prs}
+    if len(prs) > limit {
+        page.PRs = prs[:limit]
+        page.NextCursor = encodeCursor(q, page.PRs[limit-1])
+    }
+    return page, nil
+}
+
+func encodeCursor(q domain.PullRequestQuery, last *domain.PullRequest) string {
+    c := pageCursor{Sort: q.Sort, Desc: q.Desc, Value: last.CreatedAt, Repository: last.Repository, ID: last.ID}
+    if q.Sort == domain.SortByUpdatedAt {
+        c.Value = last.UpdatedAt
+    }
+    raw, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (*pageCursor, error) {
+    raw, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return nil, NewAppError(ErrorCodeBadRequest, "invalid cursor")
+    }
+    var c pageCursor
+    if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "invalid cursor")
+    }
+    return &c, nil
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "reflect"
+    "testing"
+    "time"
+)
+
+// newSearchService creates p1..p5 by a in team core and p6 by x in team
+// web, one minute apart, and merges p1 last so that it is the most
+// recently updated PR.
+func newSearchService(t *testing.T) (*Service, *fakeClock) {
+    t.Helper()
+    clock := newFakeClock()
+    s := newTestService(t, WithClock(clock.Now))
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustCreateTeam(t, s, "web", member("x"), member("y"))
+
+    for _, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+        mustCreatePR(t, s, CreatePullRequestInput{ID: id, AuthorID: "a"})
+        clock.Advance(time.Minute)
+    }
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p6", AuthorID: "x"})
+    clock.Advance(time.Minute)
+    if _, err := s.MergePullRequest(context.Background(), "", "p1"); err != nil {
+        t.Fatalf("MergePullRequest: %v", err)
+    }
+    return s, clock
+}
+
+func prIDs(prs []*domain.PullRequest) []string {
+    ids := make([]string, 0, len(prs))
+    for _, pr := range prs {
+        ids = append(ids, pr.ID)
+    }
+    return ids
+}
+
+func TestListPullRequestsPaging(t *testing.T) {
+    ctx := context.Background()
+    s, _ := newSearchService(t)
+
+    tests := []struct {
+        name string
+        sort domain.PRSortField
+        desc bool
+        want []string
+    }{
+        {"created asc", domain.SortByCreatedAt, false, []string{"p1", "p2", "p3", "p4", "p5", "p6"}},
+        {"created desc", "", true, []string{"p6", "p5", "p4", "p3", "p2", "p1"}},
+        {"updated desc", domain.SortByUpdatedAt, true, []string{"p1", "p6", "p5", "p4", "p3", "p2"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var got []string
+            in := ListPullRequestsInput{Sort: tt.sort, Desc: tt.desc, Limit: 4}
+            for pages := 0; ; pages++ {
+                if pages == len(tt.want) {
+                    t.Fatalf("paging did not stop, got %v so far", got)
+                }
+                page, err := s.ListPullRequests(ctx, in)
+                if err != nil {
+                    t.Fatalf("ListPullRequests: %v", err)
+                }
+                got = append(got, prIDs(page.PRs)...)
+                if page.NextCursor == "" {
+                    break
+                }
+                in.Cursor = page.NextCursor
+            }
+            if !reflect.DeepEqual(got, tt.want) {
+                t.Fatalf("got %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestListPullRequestsCursorSurvivesNewPullRequests(t *testing.T) {
+    ctx := context.Background()
+    s, _ := newSearchService(t)
+
+    first, err := s.ListPullRequests(ctx, ListPullRequestsInput{Desc: true, Limit: 2})
+    if err != nil {
+        t.Fatalf("ListPullRequests: %v", err)
+    }
+    // A PR created between pages sorts before the cursor and does not shift
+    // the next page.
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p7", AuthorID: "a"})
+
+    next, err := s.ListPullRequests(ctx, ListPullRequestsInput{Desc: true, Limit: 2, Cursor: first.NextCursor})
+    if err != nil {
+        t.Fatalf("ListPullRequests: %v", err)
+    }
+    if got := prIDs(next.PRs); !reflect.DeepEqual(got, []string{"p4", "p3"}) {
+        t.Fatalf("second page = %v, want [p4 p3]", got)
+    }
+}
+
+func TestListPullRequestsFilters(t *testing.T) {
+    ctx := context.Background()
+    s, _ := newSearchService(t)
+    start := newFakeClock().Now()
+    reviewer := mustGetPR(t, s, "p3").AssignedReviewers[0]
+
+    var reviewed []string
+    for _, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+        if containsString(mustGetPR(t, s, id).AssignedReviewers, reviewer) {
+            reviewed = append(reviewed, id)
+        }
+    }
+
+    tests := []struct {
+        name string
+        in   ListPullRequestsInput
+        want []string
+    }{
+        {"status", ListPullRequestsInput{Statuses: []domain.PRStatus{domain.StatusMerged}}, []string{"p1"}},
+        {"several statuses", ListPullRequestsInput{Statuses: []domain.PRStatus{domain.StatusMerged, domain.StatusDraft}}, []string{"p1"}},
+        {"author", ListPullRequestsInput{AuthorID: "x"}, []string{"p6"}},
+        {"reviewer", ListPullRequestsInput{ReviewerID: reviewer}, reviewed},
+        {"team", ListPullRequestsInput{TeamName: "web"}, []string{"p6"}},
+        {"created range", ListPullRequestsInput{CreatedFrom: start.Add(time.Minute), CreatedTo: start.Add(3 * time.Minute)}, []string{"p2", "p3"}},
+        {"combined", ListPullRequestsInput{AuthorID: "a", Statuses: []domain.PRStatus{domain.StatusOpen}, CreatedFrom: start.Add(3 * time.Minute)}, []string{"p4", "p5"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            page, err := s.ListPullRequests(ctx, tt.in)
+            if err != nil {
+                t.Fatalf("ListPullRequests: %v", err)
+            }
+            if got := prIDs(page.PRs); !reflect.DeepEqual(got, tt.want) {
+                t.Fatalf("got %v, want %v", got, tt.want)
+            }
+            if page.NextCursor != "" {
+                t.Fatalf("single page returned cursor %q", page.NextCursor)
+            }
+        })
+    }
+}
+
+func TestListPullRequestsValidation(t *testing.T) {
+    ctx := context.Background()
+    s, clock := newSearchService(t)
+
+    page, err := s.ListPullRequests(ctx, ListPullRequestsInput{Limit: 1})
+    if err != nil {
+        t.Fatalf("ListPullRequests: %v", err)
+    }
+    asc := page.NextCursor
+
+    tests := []struct {
+        name string
+        in   ListPullRequestsInput
+        code ErrorCode
+    }{
+        {"unknown status", ListPullRequestsInput{Statuses: []domain.PRStatus{"PENDING"}}, ErrorCodeBadRequest},
+        {"unknown sort", ListPullRequestsInput{Sort: "name"}, ErrorCodeBadRequest},
+        {"empty created range", ListPullRequestsInput{CreatedFrom: clock.Now(), CreatedTo: clock.Now()}, ErrorCodeBadRequest},
+        {"limit too large", ListPullRequestsInput{Limit: maxPageSize + 1}, ErrorCodeBadRequest},
+        {"unknown team", ListPullRequestsInput{TeamName: "nobody"}, ErrorCodeNotFound},
+        {"malformed cursor", ListPullRequestsInput{Cursor: "not a cursor"}, ErrorCodeBadRequest},
+        {"cursor for another order", ListPullRequestsInput{Desc: true, Cursor: asc}, ErrorCodeBadRequest},
+        {"cursor for another sort", ListPullRequestsInput{Sort: domain.SortByUpdatedAt, Cursor: asc}, ErrorCodeBadRequest},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.ListPullRequests(ctx, tt.in); !isAppError(err, tt.code) {
+                t.Fatalf("got %v, want %s", err, tt.code)
+            }
+        })
+    }
+}
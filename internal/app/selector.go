@@ -0,0 +1,234 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "fmt"
+    "math/rand"
+    "sort"
+    "sync"
+    "time"
+)
+
+const (
+    StrategyRandom      = "random"
+    StrategyRoundRobin  = "round_robin"
+    StrategyLeastLoaded = "least_loaded"
+)
+
+// ReviewerSelector picks up to limit reviewer IDs out of already filtered candidates.
+type ReviewerSelector interface {
+    Select(ctx context.Context, teamName string, candidates []*domain.User, limit int) []string
+}
+
+// SnapshotSelector is implemented by selectors that keep state between
+// calls. Snapshot returns an independent copy, so that a dry run can plan
+// with it without changing what the live selector picks next.
+type SnapshotSelector interface {
+    Snapshot() ReviewerSelector
+}
+
+// snapshotSelector returns a copy of sel if it has state, or sel itself.
+func snapshotSelector(sel ReviewerSelector) ReviewerSelector {
+    if ss, ok := sel.(SnapshotSelector); ok {
+        return ss.Snapshot()
+    }
+    return sel
+}
+
+func NewReviewerSelector(strategy string, store Store, r *rand.Rand) (ReviewerSelector, error) {
+    switch strategy {
+    case "", StrategyRandom:
+        return NewRandomSelector(r), nil
+    case StrategyRoundRobin:
+        return NewRoundRobinSelector(), nil
+    case StrategyLeastLoaded:
+        return NewLeastLoadedSelector(store, r), nil
+    default:
+        return nil, fmt.Errorf("unknown reviewer strategy %q", strategy)
+    }
+}
+
+// replaySource is a seeded rand.Source that counts its draws, so a copy at
+// the same point of the sequence can be made by replaying them.
+type replaySource struct {
+    mu    sync.Mutex
+    seed  int64
+    draws uint64
+    src   rand.Source
+}
+
+// newReplaySource seeds the source from r, or from the clock when r is nil.
+func newReplaySource(r *rand.Rand) *replaySource {
+    seed := time.Now().UnixNano()
+    if r != nil {
+        seed = r.Int63()
+    }
+    return &replaySource{seed: seed, src: rand.NewSource(seed)}
+}
+
+func (src *replaySource) Int63() int64 {
+    src.mu.Lock()
+    defer src.mu.Unlock()
+    src.draws++
+    return src.src.Int63()
+}
+
+func (src *replaySource) Seed(seed int64) {
+    src.mu.Lock()
+    defer src.mu.Unlock()
+    src.seed, src.draws = seed, 0
+    src.src.Seed(seed)
+}
+
+// clone returns a source that yields the same values src yields next.
+func (src *replaySource) clone() *replaySource {
+    src.mu.Lock()
+    defer src.mu.Unlock()
+
+    cp := &replaySource{seed: src.seed, draws: src.draws, src: rand.NewSource(src.seed)}
+    for i := uint64(0); i < src.draws; i++ {
+        cp.src.Int63()
+    }
+    return cp
+}
+
+type RandomSelector struct {
+    src  *replaySource
+    rand *rand.Rand
+}
+
+// NewRandomSelector draws its own seed from r, so selectors built from the
+// same seeded r pick the same way.
+func NewRandomSelector(r *rand.Rand) *RandomSelector {
+    return newRandomSelector(newReplaySource(r))
+}
+
+func newRandomSelector(src *replaySource) *RandomSelector {
+    return &RandomSelector{src: src, rand: rand.New(src)}
+}
+
+// Snapshot continues from a copy of the live source, so it picks exactly
+// what the live selector would pick next without consuming its draws.
+func (sel *RandomSelector) Snapshot() ReviewerSelector {
+    return newRandomSelector(sel.src.clone())
+}
+
+func (sel *RandomSelector) Select(_ context.Context, _ string, candidates []*domain.User, limit int) []string {
+    if limit <= 0 {
+        return nil
+    }
+    if len(candidates) <= limit {
+        return userIDs(candidates)
+    }
+
+    idx := sel.rand.Perm(len(candidates))[:limit]
+
+    res := make([]string, 0, limit)
+    for _, i := range idx {
+        res = append(res, candidates[i].ID)
+    }
+    return res
+}
+
+// RoundRobinSelector walks each team's members ordered by ID,
+// continuing after the last reviewer it handed out for that team.
+type RoundRobinSelector struct {
+    mu   sync.Mutex
+    last map[string]string
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+    return &RoundRobinSelector{last: make(map[string]string)}
+}
+
+func (sel *RoundRobinSelector) Snapshot() ReviewerSelector {
+    sel.mu.Lock()
+    defer sel.mu.Unlock()
+
+    last := make(map[string]string, len(sel.last))
+    for team, id := range sel.last {
+        last[team] = id
+    }
+    return &RoundRobinSelector{last: last}
+}
+
+func (sel *RoundRobinSelector) Select(_ context.Context, teamName string, candidates []*domain.User, limit int) []string {
+    if len(candidates) == 0 || limit <= 0 {
+        return nil
+    }
+
+    ids := userIDs(candidates)
+    sort.Strings(ids)
+    if limit > len(ids) {
+        limit = len(ids)
+    }
+
+    sel.mu.Lock()
+    defer sel.mu.Unlock()
+
+    start := sort.SearchStrings(ids, sel.last[teamName])
+    if start < len(ids) && ids[start] == sel.last[teamName] {
+        start++
+    }
+
+    res := make([]string, 0, limit)
+    for i := 0; i < limit; i++ {
+        res = append(res, ids[(start+i)%len(ids)])
+    }
+    sel.last[teamName] = res[len(res)-1]
+    return res
+}
+
+// LeastLoadedSelector prefers candidates with the fewest reviews on OPEN PRs,
+// breaking ties randomly.
+type LeastLoadedSelector struct {
+    store Store
+    src   *replaySource
+    rand  *rand.Rand
+}
+
+func NewLeastLoadedSelector(store Store, r *rand.Rand) *LeastLoadedSelector {
+    return newLeastLoadedSelector(store, newReplaySource(r))
+}
+
+func newLeastLoadedSelector(store Store, src *replaySource) *LeastLoadedSelector {
+    return &LeastLoadedSelector{store: store, src: src, rand: rand.New(src)}
+}
+
+// Snapshot breaks ties from a copy of the live source; see RandomSelector.
+func (sel *LeastLoadedSelector) Snapshot() ReviewerSelector {
+    return newLeastLoadedSelector(sel.store, sel.src.clone())
+}
+
+func (sel *LeastLoadedSelector) Select(ctx context.Context, _ string, candidates []*domain.User, limit int) []string {
+    if len(candidates) == 0 || limit <= 0 {
+        return nil
+    }
+    if limit > len(candidates) {
+        limit = len(candidates)
+    }
+
+    loads, err := sel.store.CountOpenReviews(ctx, userIDs(candidates))
+    if err != nil {
+        loads = map[string]int{}
+    }
+
+    shuffled := make([]*domain.User, 0, len(candidates))
+    for _, i := range sel.rand.Perm(len(candidates)) {
+        shuffled = append(shuffled, candidates[i])
+    }
+    sort.SliceStable(shuffled, func(i, j int) bool {
+        return loads[shuffled[i].ID] < loads[shuffled[j].ID]
+    })
+
+    return userIDs(shuffled[:limit])
+}
+
+func userIDs(users []*domain.User) []string {
+    res := make([]string, 0, len(users))
+    for _, u := range users {
+        res = append(res, u.ID)
+    }
+    return res
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    memory "backend-trainee-assignment/internal/infrastructure/persistance/in_memory"
+    "context"
+    "math/rand"
+    "reflect"
+    "testing"
+)
+
+func users(ids ...string) []*domain.User {
+    res := make([]*domain.User, 0, len(ids))
+    for _, id := range ids {
+        res = append(res, &domain.User{ID: id, IsActive: true})
+    }
+    return res
+}
+
+func TestNewReviewerSelector(t *testing.T) {
+    for _, strategy := range []string{"", StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded} {
+        if _, err := NewReviewerSelector(strategy, nil, rand.New(rand.NewSource(1))); err != nil {
+            t.Errorf("NewReviewerSelector(%q): %v", strategy, err)
+        }
+    }
+    if _, err := NewReviewerSelector("fastest", nil, nil); err == nil {
+        t.Error("unknown strategy was accepted")
+    }
+}
+
+func TestRandomSelectorPicksDistinctCandidates(t *testing.T) {
+    sel := NewRandomSelector(rand.New(rand.NewSource(1)))
+    ctx := context.Background()
+
+    if got := sel.Select(ctx, "core", users("a", "b"), 3); len(got) != 2 {
+        t.Fatalf("got %v, want both candidates when there are fewer than the limit", got)
+    }
+
+    got := sel.Select(ctx, "core", users("a", "b", "c", "d"), 2)
+    if len(got) != 2 || got[0] == got[1] {
+        t.Fatalf("got %v, want two distinct reviewers", got)
+    }
+}
+
+func TestRoundRobinSelectorContinuesPerTeam(t *testing.T) {
+    sel := NewRoundRobinSelector()
+    ctx := context.Background()
+    candidates := users("c", "a", "b")
+
+    var got []string
+    for i := 0; i < 4; i++ {
+        got = append(got, sel.Select(ctx, "core", candidates, 1)...)
+    }
+    if want := []string{"a", "b", "c", "a"}; !reflect.DeepEqual(got, want) {
+        t.Fatalf("core picks %v, want %v", got, want)
+    }
+
+    if got := sel.Select(ctx, "web", candidates, 2); !reflect.DeepEqual(got, []string{"a", "b"}) {
+        t.Fatalf("web picks %v, want to start from the beginning", got)
+    }
+
+    // A candidate that left the team does not break the rotation.
+    if got := sel.Select(ctx, "core", users("b", "c"), 1); !reflect.DeepEqual(got, []string{"b"}) {
+        t.Fatalf("got %v after a leaves, want b", got)
+    }
+}
+
+func TestSnapshotSelectorDoesNotAdvanceLiveState(t *testing.T) {
+    sel := NewRoundRobinSelector()
+    ctx := context.Background()
+    candidates := users("a", "b", "c")
+    sel.Select(ctx, "core", candidates, 1)
+
+    snap := snapshotSelector(sel)
+    snap.Select(ctx, "core", candidates, 1)
+    snap.Select(ctx, "core", candidates, 1)
+
+    if got := sel.Select(ctx, "core", candidates, 1); !reflect.DeepEqual(got, []string{"b"}) {
+        t.Fatalf("live selector picked %v after snapshot use, want b", got)
+    }
+}
+
+func TestRandomSelectorSnapshotReplaysLiveSource(t *testing.T) {
+    sel := NewRandomSelector(rand.New(rand.NewSource(1)))
+    ctx := context.Background()
+    candidates := users("a", "b", "c", "d", "e")
+    sel.Select(ctx, "core", candidates, 2)
+
+    snap := snapshotSelector(sel)
+    planned := [][]string{snap.Select(ctx, "core", candidates, 2), snap.Select(ctx, "core", candidates, 2)}
+    again := snapshotSelector(sel)
+    for i, want := range planned {
+        if got := again.Select(ctx, "core", candidates, 2); !reflect.DeepEqual(got, want) {
+            t.Fatalf("second snapshot pick %d: got %v, want %v", i, got, want)
+        }
+        if got := sel.Select(ctx, "core", candidates, 2); !reflect.DeepEqual(got, want) {
+            t.Fatalf("live pick %d: got %v, snapshot planned %v", i, got, want)
+        }
+    }
+}
+
+func TestSelectorsHandleNothingToPick(t *testing.T) {
+    ctx := context.Background()
+    selectors := map[string]ReviewerSelector{
+        StrategyRandom:      NewRandomSelector(rand.New(rand.NewSource(1))),
+        StrategyRoundRobin:  NewRoundRobinSelector(),
+        StrategyLeastLoaded: NewLeastLoadedSelector(memory.NewInMemoryStore(), rand.New(rand.NewSource(1))),
+    }
+    for name, sel := range selectors {
+        t.Run(name, func(t *testing.T) {
+            if got := sel.Select(ctx, "core", nil, 2); len(got) != 0 {
+                t.Errorf("no candidates: got %v", got)
+            }
+            if got := sel.Select(ctx, "core", users("a", "b"), 0); len(got) != 0 {
+                t.Errorf("zero limit: got %v", got)
+            }
+            if got := sel.Select(ctx, "core", users("a", "b"), -1); len(got) != 0 {
+                t.Errorf("negative limit: got %v", got)
+            }
+        })
+    }
+}
+
+func TestLeastLoadedSelectorPrefersIdleReviewers(t *testing.T) {
+    store := memory.NewInMemoryStore()
+    r := rand.New(rand.NewSource(1))
+    s := NewService(store, r, WithReviewerSelector(NewLeastLoadedSelector(store, r)))
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"), member("d"))
+
+    first := mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+    busy := map[string]bool{}
+    for _, id := range first.AssignedReviewers {
+        busy[id] = true
+    }
+
+    second := mustCreatePR(t, s, CreatePullRequestInput{ID: "p2", AuthorID: "a"})
+    idle := 0
+    for _, id := range second.AssignedReviewers {
+        if !busy[id] {
+            idle++
+        }
+    }
+    if idle != 1 {
+        t.Fatalf("p1 reviewers %v, p2 reviewers %v: want the idle member on p2", first.AssignedReviewers, second.AssignedReviewers)
+    }
+}
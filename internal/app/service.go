@@ -5,19 +5,65 @@ import (
     "context"
     "errors"
     "math/rand"
+    "strings"
     "time"
 )
 
 type Service struct {
     store Store
     rand  *rand.Rand
+
+    selector      ReviewerSelector
+    teamSelectors map[string]ReviewerSelector
+
+    // publisher may be nil when nobody listens to PR events.
+    publisher EventPublisher
+
+    now func() time.Time
+}
+
+type Option func(*Service)
+
+// WithClock replaces time.Now, e.g. to pin time in tests.
+func WithClock(now func() time.Time) Option {
+    return func(s *Service) {
+        s.now = now
+    }
 }
 
-func NewService(store Store, r *rand.Rand) *Service {
+// WithReviewerSelector sets the deployment-wide reviewer selection strategy.
+func WithReviewerSelector(sel ReviewerSelector) Option {
+    return func(s *Service) {
+        s.selector = sel
+    }
+}
+
+// WithTeamReviewerSelector overrides the selection strategy for a single team.
+func WithTeamReviewerSelector(teamName string, sel ReviewerSelector) Option {
+    return func(s *Service) {
+        s.teamSelectors[teamName] = sel
+    }
+}
+
+func NewService(store Store, r *rand.Rand, opts ...Option) *Service {
     if r == nil {
         r = rand.New(rand.NewSource(time.Now().UnixNano()))
     }
-    return &Service{store: store, rand: r}
+    s := &Service{
+        store:         store,
+        rand:          r,
+        teamSelectors: make(map[string]ReviewerSelector),
+        now: func() time.Time {
+            return time.Now().UTC()
+        },
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    if s.selector == nil {
+        s.selector = NewRandomSelector(r)
+    }
+    return s
 }
 
 
@@ -32,11 +78,23 @@ func (s *Service) CreateTeam(ctx context.Context, teamName string, members []Tea
             return nil, errors.New("user_id and username are required")
         }
 
+        role := m.Role
+        if role == "" {
+            role = domain.DefaultRole
+            if existing, ok := s.store.GetUserByID(ctx, m.UserID); ok && existing.Role != "" {
+                role = existing.Role
+            }
+        }
+        if !role.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "unknown role "+string(role))
+        }
+
         users = append(users, &domain.User{
             ID:       m.UserID,
             Username: m.Username,
             TeamName: teamName,
             IsActive: m.IsActive,
+            Role:     role,
         })
     }
 
@@ -58,49 +116,306 @@ func (s *Service) GetTeam(ctx context.Context, teamName string) (*TeamWithMember
     return &TeamWithMembers{Name: teamName, Members: members}, nil
 }
 
-func (s *Service) SetUserIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
-    user, ok := s.store.SetUserIsActive(ctx, userID, isActive)
+func (s *Service) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+    settings, ok := s.store.GetTeamSettings(ctx, teamName)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "team not found")
+    }
+    return settings, nil
+}
+
+func (s *Service) UpdateTeamSettings(ctx context.Context, teamName string, in TeamSettingsInput) (*domain.TeamSettings, error) {
+    settings, ok := s.store.GetTeamSettings(ctx, teamName)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "team not found")
+    }
+
+    if in.ReviewersRequired != nil {
+        if *in.ReviewersRequired < 1 {
+            return nil, NewAppError(ErrorCodeBadRequest, "reviewers_required must be at least 1")
+        }
+        settings.ReviewersRequired = *in.ReviewersRequired
+    }
+
+    if in.FallbackTeams != nil {
+        fallbackTeams := make([]string, 0, len(*in.FallbackTeams))
+        for _, name := range *in.FallbackTeams {
+            if name == teamName {
+                return nil, NewAppError(ErrorCodeBadRequest, "team cannot be its own fallback")
+            }
+            if containsString(fallbackTeams, name) {
+                return nil, NewAppError(ErrorCodeBadRequest, "duplicate fallback team "+name)
+            }
+            if !s.store.TeamExists(ctx, name) {
+                return nil, NewAppError(ErrorCodeNotFound, "fallback team "+name+" not found")
+            }
+            fallbackTeams = append(fallbackTeams, name)
+        }
+        settings.FallbackTeams = fallbackTeams
+    }
+
+    if in.DefaultMaxOpenReviews != nil {
+        if *in.DefaultMaxOpenReviews < 0 {
+            return nil, NewAppError(ErrorCodeBadRequest, "default_max_open_reviews must not be negative")
+        }
+        settings.DefaultMaxOpenReviews = *in.DefaultMaxOpenReviews
+    }
+
+    if in.AllowOverCapacity != nil {
+        settings.AllowOverCapacity = *in.AllowOverCapacity
+    }
+
+    if in.MinReviewerRole != nil {
+        if !in.MinReviewerRole.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "unknown role "+string(*in.MinReviewerRole))
+        }
+        settings.MinReviewerRole = *in.MinReviewerRole
+    }
+
+    if in.MinRoleReviewers != nil {
+        if *in.MinRoleReviewers < 0 {
+            return nil, NewAppError(ErrorCodeBadRequest, "min_role_reviewers must not be negative")
+        }
+        settings.MinRoleReviewers = *in.MinRoleReviewers
+    }
+    if settings.MinRoleReviewers > settings.ReviewersRequired {
+        return nil, NewAppError(ErrorCodeBadRequest, "min_role_reviewers must not exceed reviewers_required")
+    }
+
+    if in.RequiredApprovals != nil {
+        if *in.RequiredApprovals < 0 {
+            return nil, NewAppError(ErrorCodeBadRequest, "required_approvals must not be negative")
+        }
+        settings.RequiredApprovals = *in.RequiredApprovals
+    }
+    if settings.RequiredApprovals > settings.ReviewersRequired {
+        return nil, NewAppError(ErrorCodeBadRequest, "required_approvals must not exceed reviewers_required")
+    }
+
+    if in.ReviewSLA != nil {
+        if *in.ReviewSLA < 0 {
+            return nil, NewAppError(ErrorCodeBadRequest, "review_sla must not be negative")
+        }
+        settings.ReviewSLA = *in.ReviewSLA
+    }
+
+    if in.SLAAction != nil {
+        if !in.SLAAction.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "sla_action must be reassign or add_reviewer")
+        }
+        settings.SLAAction = *in.SLAAction
+    }
+
+    if !s.store.UpdateTeamSettings(ctx, teamName, settings) {
+        return nil, NewAppError(ErrorCodeNotFound, "team not found")
+    }
+    return settings, nil
+}
+
+func (s *Service) SetCodeOwners(ctx context.Context, teamName, content string) error {
+    if !s.store.TeamExists(ctx, teamName) {
+        return NewAppError(ErrorCodeNotFound, "team not found")
+    }
+
+    if _, err := ParseCodeOwners(content); err != nil {
+        return NewAppError(ErrorCodeBadRequest, "invalid CODEOWNERS: "+err.Error())
+    }
+
+    if !s.store.SaveCodeOwners(ctx, teamName, content) {
+        return NewAppError(ErrorCodeNotFound, "team not found")
+    }
+    return nil
+}
+
+func (s *Service) GetCodeOwners(ctx context.Context, teamName string) (string, error) {
+    if !s.store.TeamExists(ctx, teamName) {
+        return "", NewAppError(ErrorCodeNotFound, "team not found")
+    }
+
+    content, ok := s.store.GetCodeOwners(ctx, teamName)
+    if !ok {
+        return "", NewAppError(ErrorCodeNotFound, "CODEOWNERS not uploaded")
+    }
+    return content, nil
+}
+
+func (s *Service) teamSettings(ctx context.Context, teamName string) *domain.TeamSettings {
+    settings, ok := s.store.GetTeamSettings(ctx, teamName)
+    if !ok {
+        return domain.DefaultTeamSettings()
+    }
+    return settings
+}
+
+func (s *Service) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+    user, ok := s.store.GetUserByID(ctx, userID)
     if !ok {
         return nil, NewAppError(ErrorCodeNotFound, "resource not found")
     }
     return user, nil
 }
 
+func (s *Service) UpdateUser(ctx context.Context, userID string, in UserUpdateInput) (*domain.User, error) {
+    user, ok := s.store.GetUserByID(ctx, userID)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
 
-func (s *Service) CreatePullRequest(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
-    if id == "" || name == "" || authorID == "" {
-        return nil, errors.New("pull_request_id, pull_request_name and author_id are required")
+    if in.Skills != nil {
+        user.Skills = normalizeSkills(*in.Skills)
+    }
+
+    if in.MaxOpenReviews != nil {
+        if *in.MaxOpenReviews < 0 {
+            return nil, NewAppError(ErrorCodeBadRequest, "max_open_reviews must not be negative")
+        }
+        user.MaxOpenReviews = *in.MaxOpenReviews
+    }
+
+    if in.Role != nil {
+        if !in.Role.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "unknown role "+string(*in.Role))
+        }
+        user.Role = *in.Role
+    }
+
+    s.store.SaveUser(ctx, user)
+    return user, nil
+}
+
+func normalizeSkills(skills []string) []string {
+    res := make([]string, 0, len(skills))
+    for _, skill := range skills {
+        skill = strings.ToLower(strings.TrimSpace(skill))
+        if skill != "" && !containsString(res, skill) {
+            res = append(res, skill)
+        }
     }
+    return res
+}
 
-    author, ok := s.store.GetUserByID(ctx, authorID)
+// SetUserIsActive toggles the user; reactivation also tops up
+// under-staffed OPEN PRs of the user's team. Deactivation with
+// reassignOpenReviews hands the user's OPEN review slots over the same
+// way team deactivation does, leaving other reviewers' slots alone.
+func (s *Service) SetUserIsActive(ctx context.Context, userID string, isActive, reassignOpenReviews bool) (*TeamMemberResult, error) {
+    user, ok := s.store.SetUserIsActive(ctx, userID, isActive)
     if !ok {
         return nil, NewAppError(ErrorCodeNotFound, "resource not found")
     }
 
-    allMembers, _ := s.store.ListUsersByTeam(ctx, author.TeamName)
+    res := &TeamMemberResult{User: user, UpdatedPullRequests: make([]PullRequestRef, 0)}
+    switch {
+    case user.IsActive:
+        res.UpdatedPullRequests = s.topUpOpenPullRequests(ctx, user.TeamName)
+    case reassignOpenReviews:
+        changes := s.planReviewerRemoval(ctx, stringSet([]string{user.ID}), false)
+        s.applyReviewerChanges(ctx, changes)
+        for _, c := range changes {
+            res.UpdatedPullRequests = append(res.UpdatedPullRequests, PullRequestRef{Repository: c.Repository, ID: c.PullRequestID})
+        }
+    }
+    return res, nil
+}
+
 
-    candidates := make([]*domain.User, 0)
-    for _, u := range allMembers {
-        if u.IsActive && u.ID != author.ID {
-            candidates = append(candidates, u)
+func (s *Service) CreatePullRequest(ctx context.Context, in CreatePullRequestInput) (*CreatePullRequestResult, error) {
+    if in.ID == "" || in.Name == "" || in.AuthorID == "" {
+        return nil, errors.New("pull_request_id, pull_request_name and author_id are required")
+    }
+
+    if _, ok := s.store.GetUserByID(ctx, in.AuthorID); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if in.Repository != domain.DefaultRepository {
+        if _, ok := s.store.GetRepository(ctx, in.Repository); !ok {
+            return nil, NewAppError(ErrorCodeNotFound, "repository not found")
         }
     }
 
-    reviewers := s.pickRandomReviewers(candidates, 2)
+    if in.ExternalURL != "" && !isHTTPURL(in.ExternalURL) {
+        return nil, NewAppError(ErrorCodeBadRequest, "external_url must be an absolute http(s) URL")
+    }
 
+    now := s.now()
     pr := &domain.PullRequest{
-        ID:                id,
-        Name:              name,
-        AuthorID:          authorID,
+        Repository:        in.Repository,
+        ID:                in.ID,
+        Name:              in.Name,
+        AuthorID:          in.AuthorID,
         Status:            domain.StatusOpen,
-        AssignedReviewers: reviewers,
+        ExcludedReviewers: in.ExcludedReviewerIDs,
+        CreatedAt:         now,
+        UpdatedAt:         now,
+        Description:       in.Description,
+        Labels:            normalizeLabels(in.Labels),
+        SourceBranch:      in.SourceBranch,
+        TargetBranch:      in.TargetBranch,
+        ExternalURL:       in.ExternalURL,
+    }
+
+    // Drafts get reviewers only once they are marked ready.
+    if in.Draft {
+        pr.Status = domain.StatusDraft
+        if !s.createPullRequest(ctx, pr) {
+            return nil, NewAppError(ErrorCodePRExists, "PR id already exists")
+        }
+        return &CreatePullRequestResult{PR: pr}, nil
+    }
+
+    res, err := s.assignReviewers(ctx, pr, s.reviewerExclusions(ctx, pr), in.ChangedFiles, in.RequiredSkills)
+    if err != nil {
+        return nil, err
     }
 
-    if !s.store.CreatePullRequest(ctx, pr) {
+    if !s.createPullRequest(ctx, pr) {
         return nil, NewAppError(ErrorCodePRExists, "PR id already exists")
     }
+    return res, nil
+}
 
-    return pr, nil
+// assignReviewers fills the PR's empty reviewer slots without storing it.
+func (s *Service) assignReviewers(ctx context.Context, pr *domain.PullRequest, exclude map[string]struct{}, changedFiles, requiredSkills []string) (*CreatePullRequestResult, error) {
+    teamName, ownerTeams, err := s.reviewScope(ctx, pr)
+    if err != nil {
+        return nil, err
+    }
+
+    assignment, err := s.fillReviewerSlots(ctx, slotRequest{
+        teamName:       teamName,
+        ownerTeams:     ownerTeams,
+        needed:         s.teamSettings(ctx, teamName).ReviewersRequired - len(pr.AssignedReviewers),
+        assigned:       pr.AssignedReviewers,
+        exclude:        exclude,
+        changedFiles:   changedFiles,
+        requiredSkills: normalizeSkills(requiredSkills),
+    })
+    if err != nil {
+        return nil, err
+    }
+    pr.AssignedReviewers = append(pr.AssignedReviewers, assignment.reviewerIDs...)
+
+    return &CreatePullRequestResult{
+        PR:                      pr,
+        CodeOwnerReviewerIDs:    assignment.codeOwnerIDs,
+        FallbackReviewerIDs:     assignment.fallbackIDs,
+        OverCapacityReviewerIDs: assignment.overCapacityIDs,
+        UncoveredSkills:         assignment.uncoveredSkills,
+        MissingRoleReviewers:    assignment.missingRoleReviewers,
+    }, nil
+}
+
+// normalizeLabels trims labels and drops empty and duplicate ones,
+// keeping their case and order.
+func normalizeLabels(labels []string) []string {
+    res := make([]string, 0, len(labels))
+    for _, label := range labels {
+        label = strings.TrimSpace(label)
+        if label != "" && !containsString(res, label) {
+            res = append(res, label)
+        }
+    }
+    return res
 }
 
 func (s *Service) GetUserReviewPullRequests(ctx context.Context, userID string) []*domain.PullRequest {
@@ -117,100 +432,212 @@ func (s *Service) GetUserReviewPullRequests(ctx context.Context, userID string)
 }
 
 
-func (s *Service) MergePullRequest(ctx context.Context, id string) (*domain.PullRequest, error) {
-    pr, ok := s.store.GetPullRequestByID(ctx, id)
+func (s *Service) MergePullRequest(ctx context.Context, repo, id string) (*domain.PullRequest, error) {
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, id)
     if !ok {
         return nil, NewAppError(ErrorCodeNotFound, "resource not found")
     }
 
     if pr.Status != domain.StatusMerged {
-        now := time.Now().UTC()
+        if err := checkTransition(pr, domain.StatusMerged); err != nil {
+            return nil, err
+        }
+        if err := s.checkMergeable(ctx, pr); err != nil {
+            return nil, err
+        }
+        now := s.now()
         pr.Status = domain.StatusMerged
         pr.MergedAt = &now
-        s.store.UpdatePullRequest(ctx, pr)
+        s.updatePullRequest(ctx, pr, "")
     }
 
     return pr, nil
 }
 
-func (s *Service) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*domain.PullRequest, string, error) {
+// ReassignReviewer replaces oldUserID on the PR with newUserID or, when
+// newUserID is empty, with a reviewer picked like on PR creation.
+func (s *Service) ReassignReviewer(ctx context.Context, repo, prID, oldUserID, newUserID string) (*ReassignResult, error) {
     if prID == "" || oldUserID == "" {
-        return nil, "", errors.New("pull_request_id and old_user_id are required")
+        return nil, errors.New("pull_request_id and old_user_id are required")
     }
 
-    pr, ok := s.store.GetPullRequestByID(ctx, prID)
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, prID)
     if !ok {
-        return nil, "", NewAppError(ErrorCodeNotFound, "resource not found")
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
     }
 
-    if pr.Status == domain.StatusMerged {
-        return nil, "", NewAppError(ErrorCodePRMerged, "cannot reassign on merged PR")
+    if err := requireOpen(pr, "reassign"); err != nil {
+        return nil, err
     }
 
-    reviewerIndex := -1
-    for i, id := range pr.AssignedReviewers {
-        if id == oldUserID {
-            reviewerIndex = i
-            break
-        }
-    }
+    reviewerIndex := indexOf(pr.AssignedReviewers, oldUserID)
     if reviewerIndex == -1 {
-        return nil, "", NewAppError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+        return nil, NewAppError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
     }
 
-    reviewer, ok := s.store.GetUserByID(ctx, oldUserID)
-    if !ok {
-        return nil, "", NewAppError(ErrorCodeNotFound, "reviewer not found")
+    if newUserID != "" {
+        if err := s.checkCanReview(ctx, pr, newUserID); err != nil {
+            return nil, err
+        }
+        pr.AssignedReviewers[reviewerIndex] = newUserID
+        s.updatePullRequest(ctx, pr, reasonReassigned)
+        return &ReassignResult{PR: pr, ReplacedBy: newUserID}, nil
     }
 
-    allMembers, _ := s.store.ListUsersByTeam(ctx, reviewer.TeamName)
+    return s.replaceReviewer(ctx, pr, reviewerIndex, reasonReassigned)
+}
 
-    candidates := make([]*domain.User, 0)
-    for _, u := range allMembers {
-        if !u.IsActive {
-            continue
-        }
-        if u.ID == oldUserID {
-            continue
-        }
-        if containsString(pr.AssignedReviewers, u.ID) {
-            continue
+// replaceReviewer swaps the reviewer at reviewerIndex for one picked from
+// the repository's owner teams or, for PRs without a repository, from that
+// reviewer's team, followed by the fallbacks. reason goes to the PR's history.
+func (s *Service) replaceReviewer(ctx context.Context, pr *domain.PullRequest, reviewerIndex int, reason string) (*ReassignResult, error) {
+    reviewer, ok := s.store.GetUserByID(ctx, pr.AssignedReviewers[reviewerIndex])
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "reviewer not found")
+    }
+
+    teamName, ownerTeams := reviewer.TeamName, []string(nil)
+    if pr.Repository != domain.DefaultRepository {
+        var err error
+        if teamName, ownerTeams, err = s.reviewScope(ctx, pr); err != nil {
+            return nil, err
         }
-        candidates = append(candidates, u)
     }
 
-    if len(candidates) == 0 {
-        return nil, "", NewAppError(ErrorCodeNoCandidate, "no active replacement candidate in team")
+    remaining := make([]string, 0, len(pr.AssignedReviewers)-1)
+    remaining = append(remaining, pr.AssignedReviewers[:reviewerIndex]...)
+    remaining = append(remaining, pr.AssignedReviewers[reviewerIndex+1:]...)
+
+    assignment, err := s.fillReviewerSlots(ctx, slotRequest{
+        teamName:   teamName,
+        ownerTeams: ownerTeams,
+        needed:     1,
+        assigned:   remaining,
+        exclude:    s.autoExclusions(ctx, pr),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    if len(assignment.reviewerIDs) == 0 {
+        return nil, NewAppError(ErrorCodeNoCandidate, "no active replacement candidate in team")
     }
 
-    newReviewer := s.pickRandomReviewers(candidates, 1)[0]
+    newReviewer := assignment.reviewerIDs[0]
     pr.AssignedReviewers[reviewerIndex] = newReviewer
-    s.store.UpdatePullRequest(ctx, pr)
+    s.updatePullRequest(ctx, pr, reason)
+
+    return &ReassignResult{
+        PR:                      pr,
+        ReplacedBy:              newReviewer,
+        FallbackReviewerIDs:     assignment.fallbackIDs,
+        OverCapacityReviewerIDs: assignment.overCapacityIDs,
+    }, nil
+}
+
+// AddReviewer assigns userID to the PR on top of its current reviewers.
+func (s *Service) AddReviewer(ctx context.Context, repo, prID, userID string) (*domain.PullRequest, error) {
+    return s.addReviewer(ctx, repo, prID, userID, reasonManual)
+}
+
+// SelfAssignReviewer adds the caller, the actor carried by ctx, as an
+// extra reviewer.
+func (s *Service) SelfAssignReviewer(ctx context.Context, repo, prID string) (*domain.PullRequest, error) {
+    actorID := actorFrom(ctx)
+    if actorID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "caller identity is required to self-assign")
+    }
+    return s.addReviewer(ctx, repo, prID, actorID, reasonSelfAssign)
+}
+
+func (s *Service) addReviewer(ctx context.Context, repo, prID, userID, reason string) (*domain.PullRequest, error) {
+    if prID == "" || userID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "pull_request_id and user_id are required")
+    }
+
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, prID)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := requireOpen(pr, "change reviewers"); err != nil {
+        return nil, err
+    }
+
+    if err := s.checkCanReview(ctx, pr, userID); err != nil {
+        return nil, err
+    }
+
+    pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+    s.updatePullRequest(ctx, pr, reason)
+    return pr, nil
+}
+
+// RemoveReviewer unassigns userID without picking a replacement.
+func (s *Service) RemoveReviewer(ctx context.Context, repo, prID, userID string) (*domain.PullRequest, error) {
+    if prID == "" || userID == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "pull_request_id and user_id are required")
+    }
+
+    pr, ok := s.store.GetPullRequestByID(ctx, repo, prID)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "resource not found")
+    }
+    if err := requireOpen(pr, "change reviewers"); err != nil {
+        return nil, err
+    }
+
+    i := indexOf(pr.AssignedReviewers, userID)
+    if i == -1 {
+        return nil, NewAppError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+    }
 
-    return pr, newReviewer, nil
+    pr.AssignedReviewers = append(pr.AssignedReviewers[:i], pr.AssignedReviewers[i+1:]...)
+    s.updatePullRequest(ctx, pr, reasonManual)
+    return pr, nil
 }
 
+// checkCanReview enforces the invariants of automatic assignment for an
+// explicitly chosen reviewer. Capacity limits and unavailability are
+// deliberately not checked: a manual choice overrides them.
+func (s *Service) checkCanReview(ctx context.Context, pr *domain.PullRequest, userID string) error {
+    user, ok := s.store.GetUserByID(ctx, userID)
+    if !ok {
+        return NewAppError(ErrorCodeNotFound, "reviewer not found")
+    }
+    if containsString(pr.AssignedReviewers, userID) {
+        return NewAppError(ErrorCodeAlreadyAssigned, "reviewer is already assigned to this PR")
+    }
+    if !user.IsActive {
+        return NewAppError(ErrorCodeReviewerNotEligible, "reviewer is not active")
+    }
+    if userID == pr.AuthorID {
+        return NewAppError(ErrorCodeReviewerNotEligible, "author cannot review own PR")
+    }
+    if _, excluded := s.reviewerExclusions(ctx, pr)[userID]; excluded {
+        return NewAppError(ErrorCodeReviewerNotEligible, "reviewer is excluded from this PR")
+    }
+    return nil
+}
 
-func (s *Service) pickRandomReviewers(candidates []*domain.User, limit int) []string {
+func (s *Service) pickReviewers(ctx context.Context, teamName string, candidates []*domain.User, limit int) []string {
     if len(candidates) == 0 || limit <= 0 {
         return nil
     }
 
-    if len(candidates) <= limit {
-        res := make([]string, 0, len(candidates))
-        for _, u := range candidates {
-            res = append(res, u.ID)
-        }
-        return res
+    sel, ok := s.teamSelectors[teamName]
+    if !ok {
+        sel = s.selector
     }
+    return sel.Select(ctx, teamName, candidates, limit)
+}
 
-    idx := s.rand.Perm(len(candidates))[:limit]
-
-    res := make([]string, 0, limit)
-    for _, i := range idx {
-        res = append(res, candidates[i].ID)
+func indexOf(list []string, target string) int {
+    for i, v := range list {
+        if v == target {
+            return i
+        }
     }
-    return res
+    return -1
 }
 
 func containsString(list []string, target string) bool {
@@ -226,7 +653,12 @@ func (s *Service) GetStats(ctx context.Context) (*domain.Stats, error) {
     return s.store.GetStats(ctx)
 }
 
-func (s *Service) DeactivateTeamUsersAndReassignOpenPRs(ctx context.Context, teamName string) (*DeactivateTeamResult, error) {
+// DeactivateTeamUsersAndReassignOpenPRs deactivates every active member of
+// the team and replaces them on OPEN PRs. The whole outcome is planned
+// before anything is written. A dry run plans with snapshots of the
+// selectors, so it does not change later assignments and shows exactly what
+// a real run would do next.
+func (s *Service) DeactivateTeamUsersAndReassignOpenPRs(ctx context.Context, teamName string, dryRun bool) (*DeactivateTeamResult, error) {
     if teamName == "" {
         return nil, NewAppError(ErrorCodeBadRequest, "team_name is required")
     }
@@ -242,103 +674,136 @@ func (s *Service) DeactivateTeamUsersAndReassignOpenPRs(ctx context.Context, tea
 
     deactivatedIDs := make([]string, 0, len(members))
     for _, u := range members {
-        if u == nil || !u.IsActive {
-            continue
-        }
-        updated, ok := s.store.SetUserIsActive(ctx, u.ID, false)
-        if ok && !updated.IsActive {
+        if u != nil && u.IsActive {
             deactivatedIDs = append(deactivatedIDs, u.ID)
         }
     }
 
+    res := &DeactivateTeamResult{
+        TeamName:            teamName,
+        DryRun:              dryRun,
+        DeactivatedUserIDs:  deactivatedIDs,
+        UpdatedPullRequests: make([]PullRequestRef, 0),
+        Changes:             make([]ReviewerChange, 0),
+    }
     if len(deactivatedIDs) == 0 {
-        return &DeactivateTeamResult{
-            TeamName:              teamName,
-            DeactivatedUserIDs:    deactivatedIDs,
-            UpdatedPullRequestIDs: nil,
-        }, nil
+        return res, nil
+    }
+
+    planner := s
+    if dryRun {
+        planner = s.withSelectorSnapshots()
+    }
+    res.Changes = planner.planReviewerRemoval(ctx, stringSet(deactivatedIDs), true)
+    for _, c := range res.Changes {
+        res.UpdatedPullRequests = append(res.UpdatedPullRequests, PullRequestRef{Repository: c.Repository, ID: c.PullRequestID})
+    }
+
+    if !dryRun {
+        for _, id := range deactivatedIDs {
+            s.store.SetUserIsActive(ctx, id, false)
+        }
+        s.applyReviewerChanges(ctx, res.Changes)
     }
 
-    deactivatedSet := make(map[string]struct{}, len(deactivatedIDs))
-    for _, id := range deactivatedIDs {
-        deactivatedSet[id] = struct{}{}
+    return res, nil
+}
+
+// withSelectorSnapshots returns a copy of the service whose reviewer
+// selectors are snapshots of s's, for planning without side effects.
+func (s *Service) withSelectorSnapshots() *Service {
+    cp := *s
+    cp.selector = snapshotSelector(s.selector)
+    cp.teamSelectors = make(map[string]ReviewerSelector, len(s.teamSelectors))
+    for team, sel := range s.teamSelectors {
+        cp.teamSelectors[team] = snapshotSelector(sel)
     }
+    return &cp
+}
 
-    allPRs := s.store.ListPullRequests(ctx)
-    updatedPRIDs := make([]string, 0)
+// planReviewerRemoval works out new reviewer lists for OPEN PRs that lose
+// a reviewer once the removed users are dropped, and with dropInactive
+// anyone already inactive too. Replacements are picked as on PR creation; a PR left short
+// because everyone is at capacity still drops its removed reviewers.
+// Nothing is written.
+func (s *Service) planReviewerRemoval(ctx context.Context, removed map[string]struct{}, dropInactive bool) []ReviewerChange {
+    changes := make([]ReviewerChange, 0)
+    pending := make(map[string]int)
 
-    for _, pr := range allPRs {
+    for _, pr := range s.store.ListPullRequests(ctx) {
         if pr == nil || pr.Status != domain.StatusOpen {
             continue
         }
 
-        hadDeactivated := false
-        newReviewers := make([]string, 0, len(pr.AssignedReviewers))
-
+        hadRemoved := false
+        kept := make([]string, 0, len(pr.AssignedReviewers))
         for _, rid := range pr.AssignedReviewers {
             if rid == "" {
                 continue
             }
 
-            if _, isDeact := deactivatedSet[rid]; isDeact {
-                hadDeactivated = true
+            if _, gone := removed[rid]; gone {
+                hadRemoved = true
                 continue
             }
 
+            if !dropInactive {
+                kept = append(kept, rid)
+                continue
+            }
             user, ok := s.store.GetUserByID(ctx, rid)
             if !ok || !user.IsActive {
-                hadDeactivated = true
+                hadRemoved = true
                 continue
             }
 
-            newReviewers = append(newReviewers, rid)
-        }
-
-        if !hadDeactivated {
-            continue
+            kept = append(kept, rid)
         }
 
-        author, ok := s.store.GetUserByID(ctx, pr.AuthorID)
-        if !ok {
+        if !hadRemoved {
             continue
         }
 
-        teamMembers, ok := s.store.ListUsersByTeam(ctx, author.TeamName)
-        if !ok {
+        teamName, ownerTeams, err := s.reviewScope(ctx, pr)
+        if err != nil {
             continue
         }
 
-        candidates := make([]*domain.User, 0, len(teamMembers))
-        for _, u := range teamMembers {
-            if u == nil {
-                continue
-            }
-            if !u.IsActive {
-                continue
-            }
-            if u.ID == author.ID {
-                continue
-            }
-            if containsString(newReviewers, u.ID) {
-                continue
-            }
-            candidates = append(candidates, u)
+        exclude := s.autoExclusions(ctx, pr)
+        for id := range removed {
+            exclude[id] = struct{}{}
         }
 
-        needed := 2 - len(newReviewers)
-        if needed > 0 {
-            picked := s.pickRandomReviewers(candidates, needed)
-            newReviewers = append(newReviewers, picked...)
+        assignment, _ := s.fillReviewerSlots(ctx, slotRequest{
+            teamName:   teamName,
+            ownerTeams: ownerTeams,
+            needed:     s.teamSettings(ctx, teamName).ReviewersRequired - len(kept),
+            assigned:   kept,
+            exclude:    exclude,
+            pending:    pending,
+        })
+        for _, id := range assignment.reviewerIDs {
+            pending[id]++
         }
 
-        pr.AssignedReviewers = newReviewers
-        s.store.UpdatePullRequest(ctx, pr)
-        updatedPRIDs = append(updatedPRIDs, pr.ID)
+        changes = append(changes, ReviewerChange{
+            Repository:     pr.Repository,
+            PullRequestID:  pr.ID,
+            OldReviewerIDs: pr.AssignedReviewers,
+            NewReviewerIDs: append(kept, assignment.reviewerIDs...),
+        })
     }
 
-    return &DeactivateTeamResult{
-        TeamName:              teamName,
-        DeactivatedUserIDs:    deactivatedIDs,
-        UpdatedPullRequestIDs: updatedPRIDs,
-    }, nil
+    return changes
+}
+
+func (s *Service) applyReviewerChanges(ctx context.Context, changes []ReviewerChange) {
+    for _, c := range changes {
+        pr, ok := s.store.GetPullRequestByID(ctx, c.Repository, c.PullRequestID)
+        if !ok {
+            continue
+        }
+        pr.AssignedReviewers = c.NewReviewerIDs
+        s.updatePullRequest(ctx, pr, reasonDeactivated)
+    }
 }
@@ -0,0 +1,163 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "log"
+    "time"
+)
+
+// EscalateOverdueReviews handles every reviewer on an OPEN PR who has not
+// submitted a review within the home team's ReviewSLA of being assigned.
+// Depending on the team's SLAAction the slot is reassigned or another
+// reviewer joins the PR; either way an SLA_ESCALATED event is recorded so
+// the same assignment is escalated only once. Reviews nobody can take
+// over are left for the next run.
+func (s *Service) EscalateOverdueReviews(ctx context.Context) []SLAEscalation {
+    res := make([]SLAEscalation, 0)
+    now := s.now()
+
+    for _, pr := range s.store.ListPullRequests(ctx) {
+        if pr.Status != domain.StatusOpen {
+            continue
+        }
+        teamName, ownerTeams, err := s.reviewScope(ctx, pr)
+        if err != nil {
+            continue
+        }
+        settings := s.teamSettings(ctx, teamName)
+        if settings.ReviewSLA <= 0 {
+            continue
+        }
+
+        events := s.store.ListPREvents(ctx, pr.Repository, pr.ID)
+        reviewedAt := make(map[string]time.Time)
+        for _, r := range s.store.ListReviews(ctx, pr.Repository, pr.ID) {
+            reviewedAt[r.ReviewerID] = r.SubmittedAt
+        }
+
+        for _, reviewerID := range append([]string(nil), pr.AssignedReviewers...) {
+            since, escalated := reviewerAssignedAt(pr, events, reviewerID)
+            if escalated || now.Sub(since) < settings.ReviewSLA {
+                continue
+            }
+            if at, ok := reviewedAt[reviewerID]; ok && !at.Before(since) {
+                continue
+            }
+
+            esc := SLAEscalation{
+                Repository:    pr.Repository,
+                PullRequestID: pr.ID,
+                ReviewerID:    reviewerID,
+                Action:        settings.SLAAction,
+            }
+            switch settings.SLAAction {
+            case domain.SLAActionAddReviewer:
+                assignment, err := s.fillReviewerSlots(ctx, slotRequest{
+                    teamName:   teamName,
+                    ownerTeams: ownerTeams,
+                    needed:     1,
+                    assigned:   pr.AssignedReviewers,
+                    exclude:    s.autoExclusions(ctx, pr),
+                })
+                if err != nil || len(assignment.reviewerIDs) == 0 {
+                    continue
+                }
+                esc.NewReviewerID = assignment.reviewerIDs[0]
+                pr.AssignedReviewers = append(pr.AssignedReviewers, esc.NewReviewerID)
+                s.updatePullRequest(ctx, pr, reasonSLA)
+            default:
+                replaced, err := s.replaceReviewer(ctx, pr, indexOf(pr.AssignedReviewers, reviewerID), reasonSLA)
+                if err != nil {
+                    continue
+                }
+                esc.NewReviewerID = replaced.ReplacedBy
+            }
+
+            s.recordEvent(ctx, pr, domain.PREvent{
+                Type:          domain.EventSLAEscalated,
+                OldReviewerID: reviewerID,
+                NewReviewerID: esc.NewReviewerID,
+                Reason:        string(esc.Action),
+            })
+            res = append(res, esc)
+        }
+    }
+
+    return res
+}
+
+// reviewerAssignedAt returns when reviewerID last joined pr, falling back
+// to the PR's creation, and whether that assignment was already escalated.
+func reviewerAssignedAt(pr *domain.PullRequest, events []*domain.PREvent, reviewerID string) (time.Time, bool) {
+    since, escalated := pr.CreatedAt, false
+    for _, e := range events {
+        switch {
+        case e.NewReviewerID == reviewerID && e.Type != domain.EventSLAEscalated:
+            since, escalated = e.CreatedAt, false
+        case e.OldReviewerID == reviewerID && e.Type == domain.EventSLAEscalated:
+            escalated = true
+        }
+    }
+    return since, escalated
+}
+
+// SLAWorker periodically runs EscalateOverdueReviews. Time is read from
+// the service's clock, so WithClock makes it deterministic in tests.
+type SLAWorker struct {
+    svc      *Service
+    interval time.Duration
+    logger   *log.Logger
+    // ticks, when set, replaces the worker's own ticker.
+    ticks <-chan time.Time
+}
+
+type SLAWorkerOption func(*SLAWorker)
+
+// WithSLATicks makes the worker check on every value received from ticks
+// instead of every interval, e.g. to drive it from a test.
+func WithSLATicks(ticks <-chan time.Time) SLAWorkerOption {
+    return func(w *SLAWorker) {
+        w.ticks = ticks
+    }
+}
+
+// NewSLAWorker creates a worker checking every interval; logger may be nil.
+func NewSLAWorker(svc *Service, interval time.Duration, logger *log.Logger, opts ...SLAWorkerOption) *SLAWorker {
+    w := &SLAWorker{svc: svc, interval: interval, logger: logger}
+    for _, opt := range opts {
+        opt(w)
+    }
+    return w
+}
+
+// Run checks for overdue reviews on every tick until ctx is cancelled.
+func (w *SLAWorker) Run(ctx context.Context) {
+    ticks := w.ticks
+    if ticks == nil {
+        ticker := time.NewTicker(w.interval)
+        defer ticker.Stop()
+        ticks = ticker.C
+    }
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticks:
+            w.RunOnce(ctx)
+        }
+    }
+}
+
+// RunOnce performs a single check and returns the escalations it made.
+func (w *SLAWorker) RunOnce(ctx context.Context) []SLAEscalation {
+    escalations := w.svc.EscalateOverdueReviews(ctx)
+    if w.logger != nil {
+        for _, e := range escalations {
+            w.logger.Printf("review SLA missed by %s on PR %s: %s, new reviewer %q",
+                e.ReviewerID, e.PullRequestID, e.Action, e.NewReviewerID)
+        }
+    }
+    return escalations
+}
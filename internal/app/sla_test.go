@@ -0,0 +1,120 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "testing"
+    "time"
+)
+
+// newSLAService creates a team of four seniors, one reviewer per PR, and a
+// PR "p" authored by a.
+func newSLAService(t *testing.T, sla time.Duration, action domain.SLAAction) (*Service, *fakeClock, *domain.PullRequest) {
+    t.Helper()
+    clock := newFakeClock()
+    s := newTestService(t, WithClock(clock.Now))
+    mustCreateTeam(t, s, "core",
+        memberAs("a", domain.RoleSenior), memberAs("b", domain.RoleSenior),
+        memberAs("c", domain.RoleSenior), memberAs("d", domain.RoleSenior))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{
+        ReviewersRequired: intPtr(1),
+        ReviewSLA:         &sla,
+        SLAAction:         &action,
+    })
+    return s, clock, mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+}
+
+func TestSLAWorkerRunOnce(t *testing.T) {
+    tests := []struct {
+        name   string
+        sla    time.Duration
+        action domain.SLAAction
+        review bool
+        // wantEscalated is false when the reviewer must be left alone.
+        wantEscalated bool
+    }{
+        {name: "overdue reviewer is reassigned", sla: time.Hour, action: domain.SLAActionReassign, wantEscalated: true},
+        {name: "overdue reviewer gets company", sla: time.Hour, action: domain.SLAActionAddReviewer, wantEscalated: true},
+        {name: "submitted review is not overdue", sla: time.Hour, action: domain.SLAActionReassign, review: true},
+        {name: "SLA disabled", sla: 0, action: domain.SLAActionReassign},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx := context.Background()
+            s, clock, pr := newSLAService(t, tt.sla, tt.action)
+            late := pr.AssignedReviewers[0]
+            if tt.review {
+                if _, err := s.SubmitReview(ctx, SubmitReviewInput{
+                    PullRequestID: "p",
+                    ReviewerID:    late,
+                    Decision:      domain.DecisionCommented,
+                }); err != nil {
+                    t.Fatalf("SubmitReview: %v", err)
+                }
+            }
+
+            w := NewSLAWorker(s, time.Minute, nil)
+            clock.Advance(30 * time.Minute)
+            if got := w.RunOnce(ctx); len(got) != 0 {
+                t.Fatalf("escalated before the SLA ran out: %+v", got)
+            }
+
+            clock.Advance(time.Hour)
+            got := w.RunOnce(ctx)
+            if !tt.wantEscalated {
+                if len(got) != 0 {
+                    t.Fatalf("got escalations %+v, want none", got)
+                }
+                return
+            }
+
+            if len(got) != 1 || got[0].ReviewerID != late || got[0].Action != tt.action || got[0].NewReviewerID == "" {
+                t.Fatalf("got escalations %+v, want one for %s", got, late)
+            }
+            reviewers := mustGetPR(t, s, "p").AssignedReviewers
+            wantLateKept := tt.action == domain.SLAActionAddReviewer
+            if containsString(reviewers, late) != wantLateKept {
+                t.Errorf("reviewers %v: late reviewer %s kept = %v, want %v", reviewers, late, !wantLateKept, wantLateKept)
+            }
+            if !containsString(reviewers, got[0].NewReviewerID) {
+                t.Errorf("reviewers %v do not include new reviewer %s", reviewers, got[0].NewReviewerID)
+            }
+
+            // The same assignment is escalated only once.
+            if again := w.RunOnce(ctx); len(again) != 0 {
+                t.Fatalf("escalated twice: %+v", again)
+            }
+        })
+    }
+}
+
+func TestSLAWorkerRunUsesTicks(t *testing.T) {
+    s, clock, _ := newSLAService(t, time.Hour, domain.SLAActionReassign)
+    clock.Advance(2 * time.Hour)
+
+    ticks := make(chan time.Time)
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan struct{})
+    go func() {
+        NewSLAWorker(s, time.Hour, nil, WithSLATicks(ticks)).Run(ctx)
+        close(done)
+    }()
+
+    // An unbuffered send returns once Run received the tick; the second
+    // one once the first check has finished.
+    ticks <- clock.Now()
+    ticks <- clock.Now()
+    cancel()
+    <-done
+
+    escalated := 0
+    for _, e := range s.store.ListPREvents(context.Background(), "", "p") {
+        if e.Type == domain.EventSLAEscalated {
+            escalated++
+        }
+    }
+    if escalated != 1 {
+        t.Fatalf("got %d SLA escalations, want 1", escalated)
+    }
+}
@@ -3,21 +3,71 @@ package app
 import (
     "backend-trainee-assignment/internal/domain"
     "context"
+    "time"
 )
 
 type Store interface {
     CreateTeam(ctx context.Context, name string, members []*domain.User) bool
     TeamExists(ctx context.Context, name string) bool
     ListUsersByTeam(ctx context.Context, teamName string) ([]*domain.User, bool)
+    GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, bool)
+    UpdateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) bool
+    SaveCodeOwners(ctx context.Context, teamName, content string) bool
+    GetCodeOwners(ctx context.Context, teamName string) (string, bool)
 
     GetUserByID(ctx context.Context, id string) (*domain.User, bool)
     SaveUser(ctx context.Context, user *domain.User)
     SetUserIsActive(ctx context.Context, id string, isActive bool) (*domain.User, bool)
 
+    AddUnavailability(ctx context.Context, u *domain.Unavailability) bool
+    UpdateUnavailability(ctx context.Context, u *domain.Unavailability) bool
+    GetUnavailability(ctx context.Context, id int64) (*domain.Unavailability, bool)
+    ListUnavailability(ctx context.Context, userID string) []*domain.Unavailability
+    DeleteUnavailability(ctx context.Context, id int64) bool
+    UnavailableUserIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]struct{}, error)
+
+    AddReviewExclusion(ctx context.Context, e *domain.ReviewExclusion) bool
+    DeleteReviewExclusion(ctx context.Context, userID, otherUserID string) bool
+    // ListReviewExclusions returns pairs involving userID, or all pairs when it is empty.
+    ListReviewExclusions(ctx context.Context, userID string) []*domain.ReviewExclusion
+
+    CreateRepository(ctx context.Context, repo *domain.Repository) bool
+    GetRepository(ctx context.Context, name string) (*domain.Repository, bool)
+    UpdateRepository(ctx context.Context, repo *domain.Repository) bool
+    ListRepositories(ctx context.Context) []*domain.Repository
+
     CreatePullRequest(ctx context.Context, pr *domain.PullRequest) bool
-    GetPullRequestByID(ctx context.Context, id string) (*domain.PullRequest, bool)
+    GetPullRequestByID(ctx context.Context, repo, id string) (*domain.PullRequest, bool)
     UpdatePullRequest(ctx context.Context, pr *domain.PullRequest) bool
     ListPullRequests(ctx context.Context) []*domain.PullRequest
+    // SearchPullRequests returns the PRs matching q in q's order.
+    SearchPullRequests(ctx context.Context, q domain.PullRequestQuery) []*domain.PullRequest
+    // ListOpenPullRequestsByTeam returns OPEN PRs the team reviews: PRs of
+    // repositories it owns and repository-less PRs authored in it.
+    ListOpenPullRequestsByTeam(ctx context.Context, teamName string) []*domain.PullRequest
+    CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+
+    AddReviewDecline(ctx context.Context, d *domain.ReviewDecline) bool
+    ListReviewDeclines(ctx context.Context, repo, prID string) []*domain.ReviewDecline
+
+    // SaveReview replaces the reviewer's previous decision on the PR.
+    SaveReview(ctx context.Context, r *domain.Review) bool
+    ListReviews(ctx context.Context, repo, prID string) []*domain.Review
+
+    // AddPREvent appends to the PR's history, assigning the event ID.
+    AddPREvent(ctx context.Context, e *domain.PREvent) bool
+    ListPREvents(ctx context.Context, repo, prID string) []*domain.PREvent
+
+    AddWebhook(ctx context.Context, w *domain.WebhookSubscription) bool
+    DeleteWebhook(ctx context.Context, id int64) bool
+    ListWebhooks(ctx context.Context) []*domain.WebhookSubscription
+
+    // SaveIdentity replaces any mapping of the same provider login.
+    SaveIdentity(ctx context.Context, id *domain.ExternalIdentity) bool
+    GetIdentity(ctx context.Context, provider domain.Provider, login string) (*domain.ExternalIdentity, bool)
+    DeleteIdentity(ctx context.Context, provider domain.Provider, login string) bool
+    // ListIdentities returns mappings of the provider, or of all providers when it is empty.
+    ListIdentities(ctx context.Context, provider domain.Provider) []*domain.ExternalIdentity
 
      GetStats(ctx context.Context) (*domain.Stats, error)
 }
@@ -0,0 +1,74 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+// AddTeamMember puts a new or existing user into the team and tops up the
+// team's under-staffed PRs. A user moving from another team keeps their
+// skills, role and limits unless the input sets a role.
+func (s *Service) AddTeamMember(ctx context.Context, teamName string, in TeamMemberInput) (*TeamMemberResult, error) {
+    if teamName == "" || in.UserID == "" || in.Username == "" {
+        return nil, NewAppError(ErrorCodeBadRequest, "team_name, user_id and username are required")
+    }
+    if !s.store.TeamExists(ctx, teamName) {
+        return nil, NewAppError(ErrorCodeNotFound, "team not found")
+    }
+
+    user, ok := s.store.GetUserByID(ctx, in.UserID)
+    if !ok {
+        user = &domain.User{ID: in.UserID, Role: domain.DefaultRole}
+    }
+    if in.Role != "" {
+        if !in.Role.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "unknown role "+string(in.Role))
+        }
+        user.Role = in.Role
+    }
+    user.Username = in.Username
+    user.TeamName = teamName
+    user.IsActive = in.IsActive
+    s.store.SaveUser(ctx, user)
+
+    res := &TeamMemberResult{User: user}
+    if user.IsActive {
+        res.UpdatedPullRequests = s.topUpOpenPullRequests(ctx, teamName)
+    }
+    return res, nil
+}
+
+// topUpOpenPullRequests fills missing reviewer slots on OPEN PRs the team
+// reviews, e.g. after someone becomes available again. It returns the PRs
+// that got at least one new reviewer.
+func (s *Service) topUpOpenPullRequests(ctx context.Context, teamName string) []PullRequestRef {
+    updated := make([]PullRequestRef, 0)
+
+    for _, pr := range s.store.ListOpenPullRequestsByTeam(ctx, teamName) {
+        homeTeam, ownerTeams, err := s.reviewScope(ctx, pr)
+        if err != nil {
+            continue
+        }
+        needed := s.teamSettings(ctx, homeTeam).ReviewersRequired - len(pr.AssignedReviewers)
+        if needed <= 0 {
+            continue
+        }
+
+        assignment, err := s.fillReviewerSlots(ctx, slotRequest{
+            teamName:   homeTeam,
+            ownerTeams: ownerTeams,
+            needed:     needed,
+            assigned:   pr.AssignedReviewers,
+            exclude:    s.autoExclusions(ctx, pr),
+        })
+        if err != nil || len(assignment.reviewerIDs) == 0 {
+            continue
+        }
+
+        pr.AssignedReviewers = append(pr.AssignedReviewers, assignment.reviewerIDs...)
+        s.updatePullRequest(ctx, pr, reasonTopUp)
+        updated = append(updated, PullRequestRef{Repository: pr.Repository, ID: pr.ID})
+    }
+
+    return updated
+}
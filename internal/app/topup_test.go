@@ -0,0 +1,130 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "reflect"
+    "testing"
+)
+
+func TestAddTeamMemberTopsUpOpenPullRequests(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p2", AuthorID: "a"})
+    if _, err := s.MergePullRequest(ctx, "", "p2"); err != nil {
+        t.Fatalf("MergePullRequest: %v", err)
+    }
+
+    res, err := s.AddTeamMember(ctx, "core", memberAs("c", domain.RoleSenior))
+    if err != nil {
+        t.Fatalf("AddTeamMember: %v", err)
+    }
+    if want := []PullRequestRef{{ID: "p1"}}; !reflect.DeepEqual(res.UpdatedPullRequests, want) {
+        t.Fatalf("updated %v, want only the open PR %v", res.UpdatedPullRequests, want)
+    }
+    if got := mustGetPR(t, s, "p1").AssignedReviewers; !reflect.DeepEqual(got, []string{"b", "c"}) {
+        t.Fatalf("p1 reviewers %v, want [b c]", got)
+    }
+    if got := mustGetPR(t, s, "p2").AssignedReviewers; !reflect.DeepEqual(got, []string{"b"}) {
+        t.Fatalf("merged p2 reviewers %v, want unchanged [b]", got)
+    }
+}
+
+func TestReactivatedUserTopsUpOpenPullRequests(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    if _, err := s.SetUserIsActive(ctx, "c", false, false); err != nil {
+        t.Fatalf("SetUserIsActive(false): %v", err)
+    }
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+
+    res, err := s.SetUserIsActive(ctx, "c", true, false)
+    if err != nil {
+        t.Fatalf("SetUserIsActive(true): %v", err)
+    }
+    if want := []PullRequestRef{{ID: "p1"}}; !reflect.DeepEqual(res.UpdatedPullRequests, want) {
+        t.Fatalf("updated %v, want %v", res.UpdatedPullRequests, want)
+    }
+    if got := mustGetPR(t, s, "p1").AssignedReviewers; !reflect.DeepEqual(got, []string{"b", "c"}) {
+        t.Fatalf("p1 reviewers %v, want [b c]", got)
+    }
+}
+
+func TestDeactivatedUserOpenReviews(t *testing.T) {
+    tests := []struct {
+        name     string
+        reassign bool
+    }{
+        {"kept", false},
+        {"reassigned", true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx := context.Background()
+            s := newTestService(t)
+            mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+            mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+            pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+            leaving := pr.AssignedReviewers[0]
+            staying := "b"
+            if leaving == "b" {
+                staying = "c"
+            }
+
+            res, err := s.SetUserIsActive(ctx, leaving, false, tt.reassign)
+            if err != nil {
+                t.Fatalf("SetUserIsActive: %v", err)
+            }
+
+            wantUpdated, wantReviewers := []PullRequestRef{}, []string{leaving}
+            if tt.reassign {
+                wantUpdated, wantReviewers = []PullRequestRef{{ID: "p1"}}, []string{staying}
+            }
+            if !reflect.DeepEqual(res.UpdatedPullRequests, wantUpdated) {
+                t.Fatalf("updated %v, want %v", res.UpdatedPullRequests, wantUpdated)
+            }
+            if got := mustGetPR(t, s, "p1").AssignedReviewers; !reflect.DeepEqual(got, wantReviewers) {
+                t.Fatalf("reviewers %v, want %v", got, wantReviewers)
+            }
+        })
+    }
+}
+
+func TestDeactivatedUserOnlyHandsOverOwnReviews(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+    mustUpdateSettings(t, s, "core", TeamSettingsInput{ReviewersRequired: intPtr(1)})
+    for id, want := range map[string]struct{ author, reviewer string }{
+        "p1": {"a", "b"},
+        "p2": {"b", "c"},
+    } {
+        pr := mustCreatePR(t, s, CreatePullRequestInput{ID: id, AuthorID: want.author})
+        if got := pr.AssignedReviewers[0]; got != want.reviewer {
+            if _, err := s.ReassignReviewer(ctx, "", id, got, want.reviewer); err != nil {
+                t.Fatalf("ReassignReviewer(%s): %v", id, err)
+            }
+        }
+    }
+    if _, err := s.SetUserIsActive(ctx, "b", false, false); err != nil {
+        t.Fatalf("SetUserIsActive(b): %v", err)
+    }
+
+    // b is already inactive on p1, but only c's review is handed over.
+    res, err := s.SetUserIsActive(ctx, "c", false, true)
+    if err != nil {
+        t.Fatalf("SetUserIsActive(c): %v", err)
+    }
+    if want := []PullRequestRef{{ID: "p2"}}; !reflect.DeepEqual(res.UpdatedPullRequests, want) {
+        t.Fatalf("updated %v, want %v", res.UpdatedPullRequests, want)
+    }
+    if got := mustGetPR(t, s, "p1").AssignedReviewers; !reflect.DeepEqual(got, []string{"b"}) {
+        t.Fatalf("p1 reviewers %v, want untouched [b]", got)
+    }
+    if got := mustGetPR(t, s, "p2").AssignedReviewers; !reflect.DeepEqual(got, []string{"a"}) {
+        t.Fatalf("p2 reviewers %v, want [a]", got)
+    }
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *Service) AddUnavailability(ctx context.Context, in UnavailabilityInput) (*domain.Unavailability, error) {
+    if err := validateUnavailability(in); err != nil {
+        return nil, err
+    }
+
+    if _, ok := s.store.GetUserByID(ctx, in.UserID); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "user not found")
+    }
+
+    u := &domain.Unavailability{
+        UserID:   in.UserID,
+        StartsAt: in.StartsAt.UTC(),
+        EndsAt:   in.EndsAt.UTC(),
+        Reason:   in.Reason,
+    }
+    if !s.store.AddUnavailability(ctx, u) {
+        return nil, NewAppError(ErrorCodeNotFound, "user not found")
+    }
+    return u, nil
+}
+
+func (s *Service) UpdateUnavailability(ctx context.Context, id int64, in UnavailabilityInput) (*domain.Unavailability, error) {
+    u, ok := s.store.GetUnavailability(ctx, id)
+    if !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "unavailability not found")
+    }
+
+    in.UserID = u.UserID
+    if err := validateUnavailability(in); err != nil {
+        return nil, err
+    }
+
+    u.StartsAt = in.StartsAt.UTC()
+    u.EndsAt = in.EndsAt.UTC()
+    u.Reason = in.Reason
+    if !s.store.UpdateUnavailability(ctx, u) {
+        return nil, NewAppError(ErrorCodeNotFound, "unavailability not found")
+    }
+    return u, nil
+}
+
+func (s *Service) ListUnavailability(ctx context.Context, userID string) ([]*domain.Unavailability, error) {
+    if _, ok := s.store.GetUserByID(ctx, userID); !ok {
+        return nil, NewAppError(ErrorCodeNotFound, "user not found")
+    }
+    return s.store.ListUnavailability(ctx, userID), nil
+}
+
+func (s *Service) DeleteUnavailability(ctx context.Context, id int64) error {
+    if !s.store.DeleteUnavailability(ctx, id) {
+        return NewAppError(ErrorCodeNotFound, "unavailability not found")
+    }
+    return nil
+}
+
+func validateUnavailability(in UnavailabilityInput) error {
+    if in.UserID == "" {
+        return NewAppError(ErrorCodeBadRequest, "user_id is required")
+    }
+    if in.StartsAt.IsZero() || in.EndsAt.IsZero() {
+        return NewAppError(ErrorCodeBadRequest, "starts_at and ends_at are required")
+    }
+    if !in.EndsAt.After(in.StartsAt) {
+        return NewAppError(ErrorCodeBadRequest, "ends_at must be after starts_at")
+    }
+    return nil
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+    "context"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestUnavailableUsersAreNotAssigned(t *testing.T) {
+    clock := newFakeClock()
+    s := newTestService(t, WithClock(clock.Now))
+    mustCreateTeam(t, s, "core", member("a"), member("b"), member("c"))
+
+    _, err := s.AddUnavailability(context.Background(), UnavailabilityInput{
+        UserID:   "c",
+        StartsAt: clock.Now().Add(-time.Hour),
+        EndsAt:   clock.Now().Add(24 * time.Hour),
+        Reason:   "vacation",
+    })
+    if err != nil {
+        t.Fatalf("AddUnavailability: %v", err)
+    }
+
+    pr := mustCreatePR(t, s, CreatePullRequestInput{ID: "p1", AuthorID: "a"})
+    if !reflect.DeepEqual(pr.AssignedReviewers, []string{"b"}) {
+        t.Fatalf("reviewers %v during c's vacation, want [b]", pr.AssignedReviewers)
+    }
+
+    clock.Advance(48 * time.Hour)
+    pr = mustCreatePR(t, s, CreatePullRequestInput{ID: "p2", AuthorID: "a"})
+    if len(pr.AssignedReviewers) != 2 {
+        t.Fatalf("reviewers %v after c is back, want b and c", pr.AssignedReviewers)
+    }
+}
+
+func TestAddUnavailabilityValidatesPeriod(t *testing.T) {
+    s := newTestService(t)
+    mustCreateTeam(t, s, "core", member("a"))
+    now := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+    tests := []struct {
+        name string
+        in   UnavailabilityInput
+        code ErrorCode
+    }{
+        {"no start", UnavailabilityInput{UserID: "a", EndsAt: now}, ErrorCodeBadRequest},
+        {"ends before start", UnavailabilityInput{UserID: "a", StartsAt: now, EndsAt: now.Add(-time.Hour)}, ErrorCodeBadRequest},
+        {"unknown user", UnavailabilityInput{UserID: "x", StartsAt: now, EndsAt: now.Add(time.Hour)}, ErrorCodeNotFound},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.AddUnavailability(context.Background(), tt.in); !isAppError(err, tt.code) {
+                t.Fatalf("got %v, want %s", err, tt.code)
+            }
+        })
+    }
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "net/url"
+)
+
+// EventPublisher hands recorded PR events to the outside world, e.g. to
+// webhook subscribers. Publish is called synchronously after the event is
+// stored, so implementations must not block on delivery.
+type EventPublisher interface {
+    Publish(ctx context.Context, e *domain.PREvent)
+}
+
+// WithEventPublisher makes the service publish every PR event it records.
+func WithEventPublisher(p EventPublisher) Option {
+    return func(s *Service) {
+        s.publisher = p
+    }
+}
+
+// CreateWebhook subscribes rawURL to the given event types, or to all of
+// them when events is empty. An empty secret is replaced by a random one,
+// returned in the result so the receiver can verify signatures.
+func (s *Service) CreateWebhook(ctx context.Context, rawURL string, events []domain.PREventType, secret string) (*domain.WebhookSubscription, error) {
+    if !isHTTPURL(rawURL) {
+        return nil, NewAppError(ErrorCodeBadRequest, "url must be an absolute http(s) URL")
+    }
+
+    filter := make([]domain.PREventType, 0, len(events))
+    for _, e := range events {
+        if !e.Valid() {
+            return nil, NewAppError(ErrorCodeBadRequest, "unknown event type "+string(e))
+        }
+        if !containsEventType(filter, e) {
+            filter = append(filter, e)
+        }
+    }
+
+    if secret == "" {
+        buf := make([]byte, 32)
+        if _, err := rand.Read(buf); err != nil {
+            return nil, err
+        }
+        secret = hex.EncodeToString(buf)
+    }
+
+    w := &domain.WebhookSubscription{
+        URL:       rawURL,
+        Events:    filter,
+        Secret:    secret,
+        CreatedAt: s.now(),
+    }
+    if !s.store.AddWebhook(ctx, w) {
+        return nil, NewAppError(ErrorCodeBadRequest, "webhook could not be saved")
+    }
+    return w, nil
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id int64) error {
+    if !s.store.DeleteWebhook(ctx, id) {
+        return NewAppError(ErrorCodeNotFound, "webhook not found")
+    }
+    return nil
+}
+
+func (s *Service) ListWebhooks(ctx context.Context) []*domain.WebhookSubscription {
+    return s.store.ListWebhooks(ctx)
+}
+
+func isHTTPURL(raw string) bool {
+    u, err := url.Parse(raw)
+    return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func containsEventType(list []domain.PREventType, target domain.PREventType) bool {
+    for _, v := range list {
+        if v == target {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "reflect"
+    "testing"
+)
+
+type recordingPublisher struct {
+    events []domain.PREventType
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e *domain.PREvent) {
+    p.events = append(p.events, e.Type)
+}
+
+func TestServicePublishesRecordedEvents(t *testing.T) {
+    pub := &recordingPublisher{}
+    s := newTestService(t, WithEventPublisher(pub))
+    mustCreateTeam(t, s, "core", member("a"), member("b"))
+    mustCreatePR(t, s, CreatePullRequestInput{ID: "p", AuthorID: "a"})
+    if _, err := s.MergePullRequest(context.Background(), "", "p"); err != nil {
+        t.Fatalf("MergePullRequest: %v", err)
+    }
+
+    want := []domain.PREventType{domain.EventCreated, domain.EventReviewerAssigned, domain.EventMerged}
+    if !reflect.DeepEqual(pub.events, want) {
+        t.Fatalf("published %v, want %v", pub.events, want)
+    }
+}
+
+func TestCreateWebhook(t *testing.T) {
+    ctx := context.Background()
+    s := newTestService(t)
+
+    tests := []struct {
+        name   string
+        url    string
+        events []domain.PREventType
+    }{
+        {"relative url", "/hooks", nil},
+        {"non-http url", "ftp://example.com/hooks", nil},
+        {"unknown event", "https://example.com/hooks", []domain.PREventType{"OPENED"}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := s.CreateWebhook(ctx, tt.url, tt.events, ""); !isAppError(err, ErrorCodeBadRequest) {
+                t.Fatalf("got %v, want BAD_REQUEST", err)
+            }
+        })
+    }
+
+    sub, err := s.CreateWebhook(ctx, "https://example.com/hooks",
+        []domain.PREventType{domain.EventMerged, domain.EventMerged, domain.EventCreated}, "")
+    if err != nil {
+        t.Fatalf("CreateWebhook: %v", err)
+    }
+    if !reflect.DeepEqual(sub.Events, []domain.PREventType{domain.EventMerged, domain.EventCreated}) {
+        t.Fatalf("events %v, want duplicates dropped", sub.Events)
+    }
+    if len(sub.Secret) != 64 {
+        t.Fatalf("generated secret %q, want 32 random bytes in hex", sub.Secret)
+    }
+
+    if err := s.DeleteWebhook(ctx, sub.ID); err != nil {
+        t.Fatalf("DeleteWebhook: %v", err)
+    }
+    if err := s.DeleteWebhook(ctx, sub.ID); !isAppError(err, ErrorCodeNotFound) {
+        t.Fatalf("second DeleteWebhook: got %v, want NOT_FOUND", err)
+    }
+}
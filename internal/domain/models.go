@@ -8,27 +8,360 @@ type User struct {
 	Username string
 	TeamName string
 	IsActive bool
+	// Skills are lowercase tags such as "go", "sql" or "frontend".
+	Skills []string
+	// MaxOpenReviews caps reviews on OPEN PRs; zero falls back to the
+	// team's DefaultMaxOpenReviews.
+	MaxOpenReviews int
+	Role           Role
 }
 
+func (u *User) HasSkill(skill string) bool {
+	for _, s := range u.Skills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+
+type Role string
+
+const (
+	RoleJunior Role = "junior"
+	RoleMiddle Role = "middle"
+	RoleSenior Role = "senior"
+	RoleLead   Role = "lead"
+)
+
+const DefaultRole = RoleMiddle
+
+var roleRanks = map[Role]int{
+	RoleJunior: 1,
+	RoleMiddle: 2,
+	RoleSenior: 3,
+	RoleLead:   4,
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// AtLeast reports whether r is min or a more senior role.
+func (r Role) AtLeast(min Role) bool {
+	return roleRanks[r] >= roleRanks[min]
+}
+
+
+// Unavailability is a period (vacation, sick leave) during which a user
+// gets no new review assignments. EndsAt is exclusive.
+type Unavailability struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+func (u *Unavailability) Covers(t time.Time) bool {
+	return !t.Before(u.StartsAt) && t.Before(u.EndsAt)
+}
+
+
+// ReviewExclusion is a conflict of interest: neither user may review
+// the other's PRs. UserID is always ordered before OtherUserID.
+type ReviewExclusion struct {
+	UserID      string
+	OtherUserID string
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// Other returns the counterpart of userID in the pair.
+func (e *ReviewExclusion) Other(userID string) string {
+	if e.UserID == userID {
+		return e.OtherUserID
+	}
+	return e.UserID
+}
+
+
+// ReviewDecline records a reviewer turning down a review assignment.
+type ReviewDecline struct {
+	Repository    string
+	PullRequestID string
+	UserID        string
+	Reason        string
+	CreatedAt     time.Time
+}
+
+
+// Repository groups PRs; reviewers for its PRs come from OwnerTeams,
+// the first of which supplies the review settings.
+type Repository struct {
+	Name       string
+	OwnerTeams []string
+}
+
+// DefaultRepository holds PRs created without a repository. They are
+// reviewed by the author's team.
+const DefaultRepository = ""
+
 
 type Team struct {
 	Name    string
 	Members []*User
 }
 
+const DefaultReviewersRequired = 2
+
+// TeamSettings holds per-team review assignment configuration.
+type TeamSettings struct {
+	ReviewersRequired int
+	// FallbackTeams are tried in order when the team itself
+	// cannot fill every reviewer slot.
+	FallbackTeams []string
+	// DefaultMaxOpenReviews applies to members without a personal limit;
+	// zero means unlimited.
+	DefaultMaxOpenReviews int
+	// AllowOverCapacity lets assignment exceed limits when every
+	// candidate is full instead of failing with ALL_AT_CAPACITY.
+	AllowOverCapacity bool
+	// MinRoleReviewers reviewers of at least MinReviewerRole are
+	// picked before any other slot; zero disables the rule.
+	MinReviewerRole  Role
+	MinRoleReviewers int
+	// RequiredApprovals is the number of APPROVED decisions from current
+	// reviewers a PR needs before it can be merged.
+	RequiredApprovals int
+	// ReviewSLA is how long a reviewer may go without submitting a review
+	// before SLAAction escalates it; zero disables escalation.
+	ReviewSLA time.Duration
+	SLAAction SLAAction
+}
+
+func DefaultTeamSettings() *TeamSettings {
+	return &TeamSettings{
+		ReviewersRequired: DefaultReviewersRequired,
+		MinReviewerRole:   RoleSenior,
+		SLAAction:         SLAActionReassign,
+	}
+}
+
+// SLAAction is what happens to a review that missed the team's SLA.
+type SLAAction string
+
+const (
+	// SLAActionReassign hands the late reviewer's slot to someone else.
+	SLAActionReassign SLAAction = "reassign"
+	// SLAActionAddReviewer keeps the late reviewer and adds another one.
+	SLAActionAddReviewer SLAAction = "add_reviewer"
+)
+
+func (a SLAAction) Valid() bool {
+	return a == SLAActionReassign || a == SLAActionAddReviewer
+}
+
 
 type PRStatus string
 
 const (
+	StatusDraft  PRStatus = "DRAFT"
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
+// prTransitions lists the statuses a PR may move to from each status.
+// MERGED is final.
+var prTransitions = map[PRStatus][]PRStatus{
+	StatusDraft:  {StatusOpen, StatusClosed},
+	StatusOpen:   {StatusMerged, StatusClosed},
+	StatusClosed: {StatusOpen},
+}
+
+func (s PRStatus) Valid() bool {
+	switch s {
+	case StatusDraft, StatusOpen, StatusMerged, StatusClosed:
+		return true
+	}
+	return false
+}
+
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	for _, st := range prTransitions[s] {
+		if st == next {
+			return true
+		}
+	}
+	return false
+}
+
+type ReviewDecision string
+
+const (
+	DecisionApproved         ReviewDecision = "APPROVED"
+	DecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+	DecisionCommented        ReviewDecision = "COMMENTED"
+)
+
+func (d ReviewDecision) Valid() bool {
+	switch d {
+	case DecisionApproved, DecisionChangesRequested, DecisionCommented:
+		return true
+	}
+	return false
+}
+
+// Review is the latest decision a reviewer submitted on a PR.
+type Review struct {
+	Repository    string
+	PullRequestID string
+	ReviewerID    string
+	Decision      ReviewDecision
+	Comment       string
+	SubmittedAt   time.Time
+}
+
 type PullRequest struct {
+	// Repository and ID together identify the PR.
+	Repository        string
 	ID                string
 	Name              string
 	AuthorID          string
 	Status            PRStatus
 	AssignedReviewers []string
 	MergedAt          *time.Time
+	// ExcludedReviewers may never be assigned to this PR (co-authors etc).
+	ExcludedReviewers []string
+
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Description  string
+	Labels       []string
+	SourceBranch string
+	TargetBranch string
+	ExternalURL  string
+}
+
+// PRSortField is the timestamp PR searches are ordered by. Ties are broken
+// by repository and ID.
+type PRSortField string
+
+const (
+	SortByCreatedAt PRSortField = "created_at"
+	SortByUpdatedAt PRSortField = "updated_at"
+)
+
+func (f PRSortField) Valid() bool {
+	return f == SortByCreatedAt || f == SortByUpdatedAt
+}
+
+// PRCursor is the sort key of the last PR of a page; the next page starts
+// right after it.
+type PRCursor struct {
+	Value      time.Time
+	Repository string
+	ID         string
+}
+
+// PullRequestQuery filters a PR search. Empty fields match every PR.
+type PullRequestQuery struct {
+	Statuses   []PRStatus
+	AuthorID   string
+	ReviewerID string
+	// TeamName matches the PRs the team reviews: PRs of repositories it
+	// owns and repository-less PRs authored in it.
+	TeamName string
+	// CreatedFrom is inclusive, CreatedTo exclusive.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	Sort  PRSortField
+	Desc  bool
+	After *PRCursor
+	// Limit of zero returns all matches.
+	Limit int
+}
+
+type PREventType string
+
+const (
+	EventCreated          PREventType = "CREATED"
+	EventReviewerAssigned PREventType = "REVIEWER_ASSIGNED"
+	EventReviewerRemoved  PREventType = "REVIEWER_REMOVED"
+	EventReviewerReplaced PREventType = "REVIEWER_REPLACED"
+	EventStatusChanged    PREventType = "STATUS_CHANGED"
+	EventMerged           PREventType = "MERGED"
+	// EventSLAEscalated marks OldReviewerID as having missed the review
+	// SLA; Reason holds the SLAAction taken.
+	EventSLAEscalated PREventType = "SLA_ESCALATED"
+)
+
+func (t PREventType) Valid() bool {
+	switch t {
+	case EventCreated, EventReviewerAssigned, EventReviewerRemoved, EventReviewerReplaced,
+		EventStatusChanged, EventMerged, EventSLAEscalated:
+		return true
+	}
+	return false
+}
+
+// PREvent is an append-only entry of a PR's history. Only the fields
+// relevant to Type are set; an empty ActorID means the system acted.
+type PREvent struct {
+	ID            int64
+	Repository    string
+	PullRequestID string
+	Type          PREventType
+	ActorID       string
+	OldReviewerID string
+	NewReviewerID string
+	FromStatus    PRStatus
+	ToStatus      PRStatus
+	Reason        string
+	CreatedAt     time.Time
+}
+
+// WebhookSubscription receives PR events of the listed types, or of every
+// type when Events is empty, signed with Secret.
+type WebhookSubscription struct {
+	ID        int64
+	URL       string
+	Events    []PREventType
+	Secret    string
+	CreatedAt time.Time
+}
+
+func (w *WebhookSubscription) Wants(t PREventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Provider is an external code hosting service PRs can be ingested from.
+type Provider string
+
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+func (p Provider) Valid() bool {
+	return p == ProviderGitHub || p == ProviderGitLab
+}
+
+// ExternalIdentity maps a provider login to one of our users.
+type ExternalIdentity struct {
+	Provider Provider
+	Login    string
+	UserID   string
 }
@@ -3,4 +3,5 @@ package domain
 type Stats struct {
     ReviewAssignments map[string]int
     PRStatuses        map[PRStatus]int
+    DeclineReasons    map[string]int
 }
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+)
+
+func (s *InMemoryStore) AddReviewDecline(_ context.Context, d *domain.ReviewDecline) bool {
+	if d == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pullRequests[prKey{d.Repository, d.PullRequestID}]; !ok {
+		return false
+	}
+	copyD := *d
+	s.declines = append(s.declines, &copyD)
+	return true
+}
+
+func (s *InMemoryStore) ListReviewDeclines(_ context.Context, repo, prID string) []*domain.ReviewDecline {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.ReviewDecline, 0)
+	for _, d := range s.declines {
+		if d.Repository == repo && d.PullRequestID == prID {
+			copyD := *d
+			res = append(res, &copyD)
+		}
+	}
+	return res
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+)
+
+func (s *InMemoryStore) AddPREvent(_ context.Context, e *domain.PREvent) bool {
+	if e == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pullRequests[prKey{e.Repository, e.PullRequestID}]; !ok {
+		return false
+	}
+	s.nextEvent++
+	e.ID = s.nextEvent
+	copyE := *e
+	s.events = append(s.events, &copyE)
+	return true
+}
+
+func (s *InMemoryStore) ListPREvents(_ context.Context, repo, prID string) []*domain.PREvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.PREvent, 0)
+	for _, e := range s.events {
+		if e.Repository == repo && e.PullRequestID == prID {
+			copyE := *e
+			res = append(res, &copyE)
+		}
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+)
+
+func (s *InMemoryStore) AddReviewExclusion(_ context.Context, e *domain.ReviewExclusion) bool {
+	if e == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := [2]string{e.UserID, e.OtherUserID}
+	if _, exists := s.exclusions[key]; exists {
+		return false
+	}
+	copyE := *e
+	s.exclusions[key] = &copyE
+	return true
+}
+
+func (s *InMemoryStore) DeleteReviewExclusion(_ context.Context, userID, otherUserID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := [2]string{userID, otherUserID}
+	if _, exists := s.exclusions[key]; !exists {
+		return false
+	}
+	delete(s.exclusions, key)
+	return true
+}
+
+func (s *InMemoryStore) ListReviewExclusions(_ context.Context, userID string) []*domain.ReviewExclusion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.ReviewExclusion, 0)
+	for _, e := range s.exclusions {
+		if userID == "" || e.UserID == userID || e.OtherUserID == userID {
+			copyE := *e
+			res = append(res, &copyE)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].UserID != res[j].UserID {
+			return res[i].UserID < res[j].UserID
+		}
+		return res[i].OtherUserID < res[j].OtherUserID
+	})
+	return res
+}
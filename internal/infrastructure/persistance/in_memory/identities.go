@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+)
+
+type identityKey struct {
+	provider domain.Provider
+	login    string
+}
+
+func (s *InMemoryStore) SaveIdentity(_ context.Context, id *domain.ExternalIdentity) bool {
+	if id == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id.UserID]; !ok {
+		return false
+	}
+	copyID := *id
+	s.identities[identityKey{id.Provider, id.Login}] = &copyID
+	return true
+}
+
+func (s *InMemoryStore) GetIdentity(_ context.Context, provider domain.Provider, login string) (*domain.ExternalIdentity, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.identities[identityKey{provider, login}]
+	if !ok {
+		return nil, false
+	}
+	copyID := *id
+	return &copyID, true
+}
+
+func (s *InMemoryStore) DeleteIdentity(_ context.Context, provider domain.Provider, login string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := identityKey{provider, login}
+	if _, ok := s.identities[key]; !ok {
+		return false
+	}
+	delete(s.identities, key)
+	return true
+}
+
+func (s *InMemoryStore) ListIdentities(_ context.Context, provider domain.Provider) []*domain.ExternalIdentity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.ExternalIdentity, 0)
+	for _, id := range s.identities {
+		if provider == "" || id.Provider == provider {
+			copyID := *id
+			res = append(res, &copyID)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Provider != res[j].Provider {
+			return res[i].Provider < res[j].Provider
+		}
+		return res[i].Login < res[j].Login
+	})
+	return res
+}
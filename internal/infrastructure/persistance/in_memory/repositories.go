@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+)
+
+func (s *InMemoryStore) CreateRepository(_ context.Context, repo *domain.Repository) bool {
+	if repo == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.repositories[repo.Name]; exists {
+		return false
+	}
+	s.repositories[repo.Name] = copyRepository(repo)
+	return true
+}
+
+func (s *InMemoryStore) GetRepository(_ context.Context, name string) (*domain.Repository, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	repo, ok := s.repositories[name]
+	if !ok {
+		return nil, false
+	}
+	return copyRepository(repo), true
+}
+
+func (s *InMemoryStore) UpdateRepository(_ context.Context, repo *domain.Repository) bool {
+	if repo == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.repositories[repo.Name]; !exists {
+		return false
+	}
+	s.repositories[repo.Name] = copyRepository(repo)
+	return true
+}
+
+func (s *InMemoryStore) ListRepositories(_ context.Context) []*domain.Repository {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.Repository, 0, len(s.repositories))
+	for _, repo := range s.repositories {
+		res = append(res, copyRepository(repo))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func copyRepository(repo *domain.Repository) *domain.Repository {
+	copyRepo := *repo
+	copyRepo.OwnerTeams = append([]string(nil), repo.OwnerTeams...)
+	return &copyRepo
+}
+
+func containsTeam(teams []string, name string) bool {
+	for _, t := range teams {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+)
+
+func (s *InMemoryStore) SaveReview(_ context.Context, r *domain.Review) bool {
+	if r == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := prKey{r.Repository, r.PullRequestID}
+	if _, ok := s.pullRequests[key]; !ok {
+		return false
+	}
+	byReviewer, ok := s.reviews[key]
+	if !ok {
+		byReviewer = make(map[string]*domain.Review)
+		s.reviews[key] = byReviewer
+	}
+	copyR := *r
+	byReviewer[r.ReviewerID] = &copyR
+	return true
+}
+
+func (s *InMemoryStore) ListReviews(_ context.Context, repo, prID string) []*domain.Review {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := prKey{repo, prID}
+	res := make([]*domain.Review, 0, len(s.reviews[key]))
+	for _, r := range s.reviews[key] {
+		copyR := *r
+		res = append(res, &copyR)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ReviewerID < res[j].ReviewerID })
+	return res
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+)
+
+func (s *InMemoryStore) SearchPullRequests(_ context.Context, q domain.PullRequestQuery) []*domain.PullRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.PullRequest, 0)
+	for _, pr := range s.pullRequests {
+		if s.matchesQuery(pr, q) {
+			res = append(res, copyPullRequest(pr))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return before(cursorOf(res[i], q.Sort), cursorOf(res[j], q.Sort), q.Desc)
+	})
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+	return res
+}
+
+func (s *InMemoryStore) matchesQuery(pr *domain.PullRequest, q domain.PullRequestQuery) bool {
+	if len(q.Statuses) > 0 && !containsStatus(q.Statuses, pr.Status) {
+		return false
+	}
+	if q.AuthorID != "" && pr.AuthorID != q.AuthorID {
+		return false
+	}
+	if q.ReviewerID != "" && !hasReviewer(pr, q.ReviewerID) {
+		return false
+	}
+	if q.TeamName != "" && !s.reviewedByTeam(pr, q.TeamName) {
+		return false
+	}
+	if !q.CreatedFrom.IsZero() && pr.CreatedAt.Before(q.CreatedFrom) {
+		return false
+	}
+	if !q.CreatedTo.IsZero() && !pr.CreatedAt.Before(q.CreatedTo) {
+		return false
+	}
+	if q.After != nil && !before(*q.After, cursorOf(pr, q.Sort), q.Desc) {
+		return false
+	}
+	return true
+}
+
+// reviewedByTeam mirrors ListOpenPullRequestsByTeam without the status check.
+func (s *InMemoryStore) reviewedByTeam(pr *domain.PullRequest, teamName string) bool {
+	if pr.Repository != domain.DefaultRepository {
+		repo, ok := s.repositories[pr.Repository]
+		return ok && containsTeam(repo.OwnerTeams, teamName)
+	}
+	author, ok := s.users[pr.AuthorID]
+	return ok && author.TeamName == teamName
+}
+
+func cursorOf(pr *domain.PullRequest, sortBy domain.PRSortField) domain.PRCursor {
+	value := pr.CreatedAt
+	if sortBy == domain.SortByUpdatedAt {
+		value = pr.UpdatedAt
+	}
+	return domain.PRCursor{Value: value, Repository: pr.Repository, ID: pr.ID}
+}
+
+// before reports whether a comes before b in the requested order.
+func before(a, b domain.PRCursor, desc bool) bool {
+	if desc {
+		a, b = b, a
+	}
+	if !a.Value.Equal(b.Value) {
+		return a.Value.Before(b.Value)
+	}
+	if a.Repository != b.Repository {
+		return a.Repository < b.Repository
+	}
+	return a.ID < b.ID
+}
+
+func hasReviewer(pr *domain.PullRequest, userID string) bool {
+	for _, id := range pr.AssignedReviewers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(list []domain.PRStatus, s domain.PRStatus) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -3,22 +3,58 @@ package memory
 import (
 	"backend-trainee-assignment/internal/domain"
 	"context"
+	"sort"
 	"sync"
 )
 
 type InMemoryStore struct {
 	mu sync.RWMutex
 
-	teams        map[string]struct{}
+	teams        map[string]*domain.TeamSettings
 	users        map[string]*domain.User
-	pullRequests map[string]*domain.PullRequest
+	pullRequests map[prKey]*domain.PullRequest
+	codeOwners   map[string]string
+	repositories map[string]*domain.Repository
+
+	unavailability     map[int64]*domain.Unavailability
+	nextUnavailability int64
+
+	// exclusions is keyed by the ordered user pair.
+	exclusions map[[2]string]*domain.ReviewExclusion
+
+	declines []*domain.ReviewDecline
+
+	// events is append-only; IDs grow with every entry.
+	events    []*domain.PREvent
+	nextEvent int64
+
+	webhooks    map[int64]*domain.WebhookSubscription
+	nextWebhook int64
+
+	identities map[identityKey]*domain.ExternalIdentity
+
+	// reviews is keyed by PR, then reviewer ID.
+	reviews map[prKey]map[string]*domain.Review
+
+	// openReviews counts reviewer slots on OPEN PRs per user, kept in sync
+	// on every PR write so load lookups don't scan all PRs.
+	openReviews map[string]int
 }
 
 func NewInMemoryStore() *InMemoryStore {
 	return &InMemoryStore{
-		teams:        make(map[string]struct{}),
+		teams:        make(map[string]*domain.TeamSettings),
 		users:        make(map[string]*domain.User),
-		pullRequests: make(map[string]*domain.PullRequest),
+		pullRequests: make(map[prKey]*domain.PullRequest),
+		codeOwners:   make(map[string]string),
+		repositories: make(map[string]*domain.Repository),
+
+		unavailability: make(map[int64]*domain.Unavailability),
+		exclusions:     make(map[[2]string]*domain.ReviewExclusion),
+		reviews:        make(map[prKey]map[string]*domain.Review),
+		webhooks:       make(map[int64]*domain.WebhookSubscription),
+		identities:     make(map[identityKey]*domain.ExternalIdentity),
+		openReviews:  make(map[string]int),
 	}
 }
 
@@ -30,13 +66,17 @@ func (s *InMemoryStore) CreateTeam(_ context.Context, name string, members []*do
 		return false
 	}
 
-	s.teams[name] = struct{}{}
+	s.teams[name] = domain.DefaultTeamSettings()
 	for _, u := range members {
 		if u == nil {
 			continue
 		}
-		copyUser := *u
-		s.users[u.ID] = &copyUser
+		stored := copyUser(u)
+		if existing, ok := s.users[u.ID]; ok {
+			stored.Skills = existing.Skills
+			stored.MaxOpenReviews = existing.MaxOpenReviews
+		}
+		s.users[u.ID] = stored
 	}
 
 	return true
@@ -61,14 +101,65 @@ func (s *InMemoryStore) ListUsersByTeam(_ context.Context, teamName string) ([]*
 	var res []*domain.User
 	for _, u := range s.users {
 		if u.TeamName == teamName {
-			copyUser := *u
-			res = append(res, &copyUser)
+			res = append(res, copyUser(u))
 		}
 	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
 
 	return res, true
 }
 
+func (s *InMemoryStore) GetTeamSettings(_ context.Context, teamName string) (*domain.TeamSettings, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings, ok := s.teams[teamName]
+	if !ok {
+		return nil, false
+	}
+	return copyTeamSettings(settings), true
+}
+
+func (s *InMemoryStore) UpdateTeamSettings(_ context.Context, teamName string, settings *domain.TeamSettings) bool {
+	if settings == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[teamName]; !ok {
+		return false
+	}
+	s.teams[teamName] = copyTeamSettings(settings)
+	return true
+}
+
+func copyTeamSettings(settings *domain.TeamSettings) *domain.TeamSettings {
+	copySettings := *settings
+	copySettings.FallbackTeams = append([]string(nil), settings.FallbackTeams...)
+	return &copySettings
+}
+
+func (s *InMemoryStore) SaveCodeOwners(_ context.Context, teamName, content string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[teamName]; !ok {
+		return false
+	}
+	s.codeOwners[teamName] = content
+	return true
+}
+
+func (s *InMemoryStore) GetCodeOwners(_ context.Context, teamName string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	content, ok := s.codeOwners[teamName]
+	return content, ok
+}
+
 func (s *InMemoryStore) GetUserByID(_ context.Context, id string) (*domain.User, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -77,8 +168,7 @@ func (s *InMemoryStore) GetUserByID(_ context.Context, id string) (*domain.User,
 	if !ok {
 		return nil, false
 	}
-	copyUser := *u
-	return &copyUser, true
+	return copyUser(u), true
 }
 
 func (s *InMemoryStore) SaveUser(_ context.Context, user *domain.User) {
@@ -88,8 +178,7 @@ func (s *InMemoryStore) SaveUser(_ context.Context, user *domain.User) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	copyUser := *user
-	s.users[user.ID] = &copyUser
+	s.users[user.ID] = copyUser(user)
 }
 
 func (s *InMemoryStore) SetUserIsActive(_ context.Context, id string, isActive bool) (*domain.User, bool) {
@@ -102,8 +191,13 @@ func (s *InMemoryStore) SetUserIsActive(_ context.Context, id string, isActive b
 	}
 
 	u.IsActive = isActive
-	copyUser := *u
-	return &copyUser, true
+	return copyUser(u), true
+}
+
+func copyUser(u *domain.User) *domain.User {
+	c := *u
+	c.Skills = append([]string(nil), u.Skills...)
+	return &c
 }
 
 func (s *InMemoryStore) CreatePullRequest(_ context.Context, pr *domain.PullRequest) bool {
@@ -114,28 +208,26 @@ func (s *InMemoryStore) CreatePullRequest(_ context.Context, pr *domain.PullRequ
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.pullRequests[pr.ID]; exists {
+	key := prKey{pr.Repository, pr.ID}
+	if _, exists := s.pullRequests[key]; exists {
 		return false
 	}
 
-	copyPR := *pr
-	s.pullRequests[pr.ID] = &copyPR
+	copyPR := copyPullRequest(pr)
+	s.pullRequests[key] = copyPR
+	s.trackOpenReviews(copyPR, 1)
 	return true
 }
 
-func (s *InMemoryStore) GetPullRequestByID(_ context.Context, id string) (*domain.PullRequest, bool) {
+func (s *InMemoryStore) GetPullRequestByID(_ context.Context, repo, id string) (*domain.PullRequest, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	pr, ok := s.pullRequests[id]
+	pr, ok := s.pullRequests[prKey{repo, id}]
 	if !ok {
 		return nil, false
 	}
-	copyPR := *pr
-	if pr.AssignedReviewers != nil {
-		copyPR.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
-	}
-	return &copyPR, true
+	return copyPullRequest(pr), true
 }
 
 func (s *InMemoryStore) UpdatePullRequest(_ context.Context, pr *domain.PullRequest) bool {
@@ -146,16 +238,54 @@ func (s *InMemoryStore) UpdatePullRequest(_ context.Context, pr *domain.PullRequ
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.pullRequests[pr.ID]; !exists {
+	key := prKey{pr.Repository, pr.ID}
+	prev, exists := s.pullRequests[key]
+	if !exists {
 		return false
 	}
 
+	copyPR := copyPullRequest(pr)
+	s.trackOpenReviews(prev, -1)
+	s.pullRequests[key] = copyPR
+	s.trackOpenReviews(copyPR, 1)
+	return true
+}
+
+// prKey identifies a PR: IDs are unique within a repository only.
+type prKey struct {
+	repo string
+	id   string
+}
+
+func sortPullRequests(prs []*domain.PullRequest) {
+	sort.Slice(prs, func(i, j int) bool {
+		if prs[i].Repository != prs[j].Repository {
+			return prs[i].Repository < prs[j].Repository
+		}
+		return prs[i].ID < prs[j].ID
+	})
+}
+
+func copyPullRequest(pr *domain.PullRequest) *domain.PullRequest {
 	copyPR := *pr
 	if pr.AssignedReviewers != nil {
 		copyPR.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
 	}
-	s.pullRequests[pr.ID] = &copyPR
-	return true
+	copyPR.ExcludedReviewers = append([]string(nil), pr.ExcludedReviewers...)
+	copyPR.Labels = append([]string(nil), pr.Labels...)
+	return &copyPR
+}
+
+func (s *InMemoryStore) trackOpenReviews(pr *domain.PullRequest, delta int) {
+	if pr.Status != domain.StatusOpen {
+		return
+	}
+	for _, reviewer := range pr.AssignedReviewers {
+		s.openReviews[reviewer] += delta
+		if s.openReviews[reviewer] <= 0 {
+			delete(s.openReviews, reviewer)
+		}
+	}
 }
 
 func (s *InMemoryStore) ListPullRequests(_ context.Context) []*domain.PullRequest {
@@ -164,21 +294,53 @@ func (s *InMemoryStore) ListPullRequests(_ context.Context) []*domain.PullReques
 
 	res := make([]*domain.PullRequest, 0, len(s.pullRequests))
 	for _, pr := range s.pullRequests {
-		copyPR := *pr
-		if pr.AssignedReviewers != nil {
-			copyPR.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
+		res = append(res, copyPullRequest(pr))
+	}
+	sortPullRequests(res)
+	return res
+}
+
+func (s *InMemoryStore) ListOpenPullRequestsByTeam(_ context.Context, teamName string) []*domain.PullRequest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.PullRequest, 0)
+	for _, pr := range s.pullRequests {
+		if pr.Status != domain.StatusOpen {
+			continue
+		}
+		if pr.Repository != domain.DefaultRepository {
+			if repo, ok := s.repositories[pr.Repository]; ok && containsTeam(repo.OwnerTeams, teamName) {
+				res = append(res, copyPullRequest(pr))
+			}
+			continue
+		}
+		if author, ok := s.users[pr.AuthorID]; ok && author.TeamName == teamName {
+			res = append(res, copyPullRequest(pr))
 		}
-		res = append(res, &copyPR)
 	}
+	sortPullRequests(res)
 	return res
 }
 
+func (s *InMemoryStore) CountOpenReviews(_ context.Context, userIDs []string) (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		res[id] = s.openReviews[id]
+	}
+	return res, nil
+}
+
 func (s *InMemoryStore) GetStats(_ context.Context) (*domain.Stats, error) {
     s.mu.RLock()
     defer s.mu.RUnlock()
 
     reviewCount := make(map[string]int)
     statusCount := make(map[domain.PRStatus]int)
+    declineCount := make(map[string]int)
 
     for _, pr := range s.pullRequests {
         statusCount[pr.Status]++
@@ -188,8 +350,13 @@ func (s *InMemoryStore) GetStats(_ context.Context) (*domain.Stats, error) {
         }
     }
 
+    for _, d := range s.declines {
+        declineCount[d.Reason]++
+    }
+
     return &domain.Stats{
         ReviewAssignments: reviewCount,
         PRStatuses:        statusCount,
+        DeclineReasons:    declineCount,
     }, nil
 }
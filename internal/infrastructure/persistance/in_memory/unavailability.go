@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+	"time"
+)
+
+func (s *InMemoryStore) AddUnavailability(_ context.Context, u *domain.Unavailability) bool {
+	if u == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[u.UserID]; !ok {
+		return false
+	}
+
+	s.nextUnavailability++
+	u.ID = s.nextUnavailability
+	copyU := *u
+	s.unavailability[u.ID] = &copyU
+	return true
+}
+
+func (s *InMemoryStore) UpdateUnavailability(_ context.Context, u *domain.Unavailability) bool {
+	if u == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.unavailability[u.ID]; !ok {
+		return false
+	}
+	copyU := *u
+	s.unavailability[u.ID] = &copyU
+	return true
+}
+
+func (s *InMemoryStore) GetUnavailability(_ context.Context, id int64) (*domain.Unavailability, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.unavailability[id]
+	if !ok {
+		return nil, false
+	}
+	copyU := *u
+	return &copyU, true
+}
+
+func (s *InMemoryStore) ListUnavailability(_ context.Context, userID string) []*domain.Unavailability {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.Unavailability, 0)
+	for _, u := range s.unavailability {
+		if u.UserID == userID {
+			copyU := *u
+			res = append(res, &copyU)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].StartsAt.Before(res[j].StartsAt)
+	})
+	return res
+}
+
+func (s *InMemoryStore) DeleteUnavailability(_ context.Context, id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.unavailability[id]; !ok {
+		return false
+	}
+	delete(s.unavailability, id)
+	return true
+}
+
+func (s *InMemoryStore) UnavailableUserIDs(_ context.Context, userIDs []string, at time.Time) (map[string]struct{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = struct{}{}
+	}
+
+	res := make(map[string]struct{})
+	for _, u := range s.unavailability {
+		if _, ok := wanted[u.UserID]; ok && u.Covers(at) {
+			res[u.UserID] = struct{}{}
+		}
+	}
+	return res, nil
+}
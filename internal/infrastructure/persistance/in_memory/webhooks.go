@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"sort"
+)
+
+func (s *InMemoryStore) AddWebhook(_ context.Context, w *domain.WebhookSubscription) bool {
+	if w == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextWebhook++
+	w.ID = s.nextWebhook
+	s.webhooks[w.ID] = copyWebhook(w)
+	return true
+}
+
+func (s *InMemoryStore) DeleteWebhook(_ context.Context, id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return false
+	}
+	delete(s.webhooks, id)
+	return true
+}
+
+func (s *InMemoryStore) ListWebhooks(_ context.Context) []*domain.WebhookSubscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]*domain.WebhookSubscription, 0, len(s.webhooks))
+	for _, w := range s.webhooks {
+		res = append(res, copyWebhook(w))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+func copyWebhook(w *domain.WebhookSubscription) *domain.WebhookSubscription {
+	copyW := *w
+	copyW.Events = append([]domain.PREventType(nil), w.Events...)
+	return &copyW
+}
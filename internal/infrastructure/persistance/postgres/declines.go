@@ -0,0 +1,36 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *PostgresStore) AddReviewDecline(ctx context.Context, d *domain.ReviewDecline) bool {
+    _, err := s.db.ExecContext(ctx,
+        `INSERT INTO review_declines (repository, pull_request_id, user_id, reason, created_at)
+         VALUES ($1,$2,$3,$4,$5)`,
+        d.Repository, d.PullRequestID, d.UserID, d.Reason, d.CreatedAt)
+    return err == nil
+}
+
+func (s *PostgresStore) ListReviewDeclines(ctx context.Context, repo, prID string) []*domain.ReviewDecline {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT repository, pull_request_id, user_id, reason, created_at
+         FROM review_declines
+         WHERE repository = $1 AND pull_request_id = $2
+         ORDER BY id`, repo, prID)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.ReviewDecline{}
+    for rows.Next() {
+        d := domain.ReviewDecline{}
+        if err := rows.Scan(&d.Repository, &d.PullRequestID, &d.UserID, &d.Reason, &d.CreatedAt); err != nil {
+            return nil
+        }
+        list = append(list, &d)
+    }
+    return list
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *PostgresStore) AddPREvent(ctx context.Context, e *domain.PREvent) bool {
+    err := s.db.QueryRowContext(ctx,
+        `INSERT INTO pr_events (repository, pull_request_id, type, actor_id, old_reviewer_id,
+                                new_reviewer_id, from_status, to_status, reason, created_at)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+         RETURNING id`,
+        e.Repository, e.PullRequestID, e.Type, e.ActorID, e.OldReviewerID,
+        e.NewReviewerID, e.FromStatus, e.ToStatus, e.Reason, e.CreatedAt,
+    ).Scan(&e.ID)
+    return err == nil
+}
+
+func (s *PostgresStore) ListPREvents(ctx context.Context, repo, prID string) []*domain.PREvent {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT id, repository, pull_request_id, type, actor_id, old_reviewer_id,
+                new_reviewer_id, from_status, to_status, reason, created_at
+         FROM pr_events
+         WHERE repository = $1 AND pull_request_id = $2
+         ORDER BY id`, repo, prID)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.PREvent{}
+    for rows.Next() {
+        e := domain.PREvent{}
+        if err := rows.Scan(&e.ID, &e.Repository, &e.PullRequestID, &e.Type, &e.ActorID, &e.OldReviewerID,
+            &e.NewReviewerID, &e.FromStatus, &e.ToStatus, &e.Reason, &e.CreatedAt); err != nil {
+            return nil
+        }
+        list = append(list, &e)
+    }
+    return list
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *PostgresStore) AddReviewExclusion(ctx context.Context, e *domain.ReviewExclusion) bool {
+    res, err := s.db.ExecContext(ctx,
+        `INSERT INTO review_exclusions (user_a, user_b, reason, created_at)
+         VALUES ($1,$2,$3,$4)
+         ON CONFLICT DO NOTHING`,
+        e.UserID, e.OtherUserID, e.Reason, e.CreatedAt)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) DeleteReviewExclusion(ctx context.Context, userID, otherUserID string) bool {
+    res, err := s.db.ExecContext(ctx,
+        `DELETE FROM review_exclusions WHERE user_a=$1 AND user_b=$2`,
+        userID, otherUserID)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) ListReviewExclusions(ctx context.Context, userID string) []*domain.ReviewExclusion {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT user_a, user_b, reason, created_at
+         FROM review_exclusions
+         WHERE $1 = '' OR user_a = $1 OR user_b = $1
+         ORDER BY user_a, user_b`, userID)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.ReviewExclusion{}
+    for rows.Next() {
+        e := domain.ReviewExclusion{}
+        if err := rows.Scan(&e.UserID, &e.OtherUserID, &e.Reason, &e.CreatedAt); err != nil {
+            return nil
+        }
+        list = append(list, &e)
+    }
+    return list
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *PostgresStore) SaveIdentity(ctx context.Context, id *domain.ExternalIdentity) bool {
+    _, err := s.db.ExecContext(ctx,
+        `INSERT INTO external_identities (provider, login, user_id)
+         VALUES ($1,$2,$3)
+         ON CONFLICT (provider, login) DO UPDATE SET user_id=EXCLUDED.user_id`,
+        id.Provider, id.Login, id.UserID)
+    return err == nil
+}
+
+func (s *PostgresStore) GetIdentity(ctx context.Context, provider domain.Provider, login string) (*domain.ExternalIdentity, bool) {
+    id := domain.ExternalIdentity{}
+    err := s.db.QueryRowContext(ctx,
+        `SELECT provider, login, user_id FROM external_identities
+         WHERE provider=$1 AND login=$2`, provider, login,
+    ).Scan(&id.Provider, &id.Login, &id.UserID)
+    if err != nil {
+        return nil, false
+    }
+    return &id, true
+}
+
+func (s *PostgresStore) DeleteIdentity(ctx context.Context, provider domain.Provider, login string) bool {
+    res, err := s.db.ExecContext(ctx,
+        `DELETE FROM external_identities WHERE provider=$1 AND login=$2`,
+        provider, login)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) ListIdentities(ctx context.Context, provider domain.Provider) []*domain.ExternalIdentity {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT provider, login, user_id
+         FROM external_identities
+         WHERE $1 = '' OR provider = $1
+         ORDER BY provider, login`, provider)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.ExternalIdentity{}
+    for rows.Next() {
+        id := domain.ExternalIdentity{}
+        if err := rows.Scan(&id.Provider, &id.Login, &id.UserID); err != nil {
+            return nil
+        }
+        list = append(list, &id)
+    }
+    return list
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+
+    "github.com/lib/pq"
+)
+
+func (s *PostgresStore) CreateRepository(ctx context.Context, repo *domain.Repository) bool {
+    res, err := s.db.ExecContext(ctx,
+        `INSERT INTO repositories (name, owner_teams)
+         VALUES ($1,$2)
+         ON CONFLICT DO NOTHING`,
+        repo.Name, nonNil(repo.OwnerTeams))
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) GetRepository(ctx context.Context, name string) (*domain.Repository, bool) {
+    repo := domain.Repository{}
+    var owners pq.StringArray
+    err := s.db.QueryRowContext(ctx,
+        `SELECT name, owner_teams FROM repositories WHERE name=$1`, name,
+    ).Scan(&repo.Name, &owners)
+    if err != nil {
+        return nil, false
+    }
+    repo.OwnerTeams = owners
+    return &repo, true
+}
+
+func (s *PostgresStore) UpdateRepository(ctx context.Context, repo *domain.Repository) bool {
+    res, err := s.db.ExecContext(ctx,
+        `UPDATE repositories SET owner_teams=$2 WHERE name=$1`,
+        repo.Name, nonNil(repo.OwnerTeams))
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) ListRepositories(ctx context.Context) []*domain.Repository {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT name, owner_teams FROM repositories ORDER BY name`)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.Repository{}
+    for rows.Next() {
+        repo := domain.Repository{}
+        var owners pq.StringArray
+        if err := rows.Scan(&repo.Name, &owners); err != nil {
+            return nil
+        }
+        repo.OwnerTeams = owners
+        list = append(list, &repo)
+    }
+    return list
+}
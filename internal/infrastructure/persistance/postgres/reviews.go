@@ -0,0 +1,40 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+)
+
+func (s *PostgresStore) SaveReview(ctx context.Context, r *domain.Review) bool {
+    _, err := s.db.ExecContext(ctx,
+        `INSERT INTO review_decisions (repository, pull_request_id, reviewer_id, decision, comment, submitted_at)
+         VALUES ($1,$2,$3,$4,$5,$6)
+         ON CONFLICT (repository, pull_request_id, reviewer_id) DO UPDATE
+            SET decision=EXCLUDED.decision,
+                comment=EXCLUDED.comment,
+                submitted_at=EXCLUDED.submitted_at`,
+        r.Repository, r.PullRequestID, r.ReviewerID, r.Decision, r.Comment, r.SubmittedAt)
+    return err == nil
+}
+
+func (s *PostgresStore) ListReviews(ctx context.Context, repo, prID string) []*domain.Review {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT repository, pull_request_id, reviewer_id, decision, comment, submitted_at
+         FROM review_decisions
+         WHERE repository = $1 AND pull_request_id = $2
+         ORDER BY reviewer_id`, repo, prID)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.Review{}
+    for rows.Next() {
+        r := domain.Review{}
+        if err := rows.Scan(&r.Repository, &r.PullRequestID, &r.ReviewerID, &r.Decision, &r.Comment, &r.SubmittedAt); err != nil {
+            return nil
+        }
+        list = append(list, &r)
+    }
+    return list
+}
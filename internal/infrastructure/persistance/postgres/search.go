@@ -0,0 +1,81 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "strconv"
+    "strings"
+
+    "github.com/lib/pq"
+)
+
+func (s *PostgresStore) SearchPullRequests(ctx context.Context, q domain.PullRequestQuery) []*domain.PullRequest {
+    var where []string
+    var args []any
+    arg := func(v any) string {
+        args = append(args, v)
+        return "$" + strconv.Itoa(len(args))
+    }
+
+    if len(q.Statuses) > 0 {
+        statuses := make([]string, 0, len(q.Statuses))
+        for _, st := range q.Statuses {
+            statuses = append(statuses, string(st))
+        }
+        where = append(where, "status = ANY("+arg(pq.StringArray(statuses))+")")
+    }
+    if q.AuthorID != "" {
+        where = append(where, "author_id = "+arg(q.AuthorID))
+    }
+    if q.ReviewerID != "" {
+        where = append(where, "reviewers @> ARRAY["+arg(q.ReviewerID)+"]::text[]")
+    }
+    if q.TeamName != "" {
+        team := arg(q.TeamName)
+        where = append(where, `(repository IN (SELECT name FROM repositories WHERE `+team+` = ANY(owner_teams))
+                 OR (repository = '' AND author_id IN (SELECT id FROM users WHERE team_name = `+team+`)))`)
+    }
+    if !q.CreatedFrom.IsZero() {
+        where = append(where, "created_at >= "+arg(q.CreatedFrom))
+    }
+    if !q.CreatedTo.IsZero() {
+        where = append(where, "created_at < "+arg(q.CreatedTo))
+    }
+
+    column, dir, cmp := "created_at", "ASC", ">"
+    if q.Sort == domain.SortByUpdatedAt {
+        column = "updated_at"
+    }
+    if q.Desc {
+        dir, cmp = "DESC", "<"
+    }
+    if q.After != nil {
+        where = append(where, "("+column+", repository, id) "+cmp+
+            " ("+arg(q.After.Value)+", "+arg(q.After.Repository)+", "+arg(q.After.ID)+")")
+    }
+
+    query := `SELECT ` + prColumns + ` FROM pull_requests`
+    if len(where) > 0 {
+        query += ` WHERE ` + strings.Join(where, " AND ")
+    }
+    query += ` ORDER BY ` + column + ` ` + dir + `, repository ` + dir + `, id ` + dir
+    if q.Limit > 0 {
+        query += ` LIMIT ` + arg(q.Limit)
+    }
+
+    rows, err := s.db.QueryContext(ctx, query, args...)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.PullRequest{}
+    for rows.Next() {
+        pr, err := scanPullRequest(rows)
+        if err != nil {
+            continue
+        }
+        list = append(list, pr)
+    }
+    return list
+}
@@ -4,6 +4,7 @@ import (
 	"backend-trainee-assignment/internal/domain"
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/lib/pq"
 )
@@ -39,13 +40,14 @@ func (s *PostgresStore) CreateTeam(ctx context.Context, name string, members []*
 
     for _, u := range members {
         _, err = tx.ExecContext(ctx,
-            `INSERT INTO users (id, username, team_name, is_active)
-             VALUES ($1,$2,$3,$4)
+            `INSERT INTO users (id, username, team_name, is_active, role)
+             VALUES ($1,$2,$3,$4,$5)
              ON CONFLICT (id) DO UPDATE 
                 SET username=EXCLUDED.username,
                     team_name=EXCLUDED.team_name,
-                    is_active=EXCLUDED.is_active`,
-            u.ID, u.Username, name, u.IsActive,
+                    is_active=EXCLUDED.is_active,
+                    role=EXCLUDED.role`,
+            u.ID, u.Username, name, u.IsActive, u.Role,
         )
         if err != nil {
             return false
@@ -64,8 +66,9 @@ func (s *PostgresStore) TeamExists(ctx context.Context, name string) bool {
 
 func (s *PostgresStore) ListUsersByTeam(ctx context.Context, teamName string) ([]*domain.User, bool) {
     rows, err := s.db.QueryContext(ctx,
-        `SELECT id, username, team_name, is_active 
-           FROM users WHERE team_name=$1`, teamName)
+        `SELECT id, username, team_name, is_active, skills, max_open_reviews, role
+           FROM users WHERE team_name=$1
+          ORDER BY id`, teamName)
     if err != nil {
         return nil, false
     }
@@ -74,35 +77,113 @@ func (s *PostgresStore) ListUsersByTeam(ctx context.Context, teamName string) ([
     users := []*domain.User{}
     for rows.Next() {
         u := domain.User{}
-        if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+        var skills pq.StringArray
+        if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &skills, &u.MaxOpenReviews, &u.Role); err != nil {
             return nil, false
         }
+        u.Skills = skills
         users = append(users, &u)
     }
 
     return users, true
 }
 
+func (s *PostgresStore) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, bool) {
+    settings := domain.TeamSettings{}
+    var fallbackTeams pq.StringArray
+    var slaSeconds int64
+    err := s.db.QueryRowContext(ctx,
+        `SELECT reviewers_required, fallback_teams, default_max_open_reviews, allow_over_capacity,
+                min_reviewer_role, min_role_reviewers, required_approvals,
+                review_sla_seconds, sla_action
+         FROM teams WHERE name=$1`, teamName).
+        Scan(&settings.ReviewersRequired, &fallbackTeams, &settings.DefaultMaxOpenReviews, &settings.AllowOverCapacity,
+            &settings.MinReviewerRole, &settings.MinRoleReviewers, &settings.RequiredApprovals,
+            &slaSeconds, &settings.SLAAction)
+    if err != nil {
+        return nil, false
+    }
+    settings.FallbackTeams = fallbackTeams
+    settings.ReviewSLA = time.Duration(slaSeconds) * time.Second
+    return &settings, true
+}
+
+func (s *PostgresStore) UpdateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) bool {
+    fallbackTeams := settings.FallbackTeams
+    if fallbackTeams == nil {
+        fallbackTeams = []string{}
+    }
+
+    res, err := s.db.ExecContext(ctx,
+        `UPDATE teams
+            SET reviewers_required=$2, fallback_teams=$3,
+                default_max_open_reviews=$4, allow_over_capacity=$5,
+                min_reviewer_role=$6, min_role_reviewers=$7, required_approvals=$8,
+                review_sla_seconds=$9, sla_action=$10
+          WHERE name=$1`,
+        teamName, settings.ReviewersRequired, pq.StringArray(fallbackTeams),
+        settings.DefaultMaxOpenReviews, settings.AllowOverCapacity,
+        settings.MinReviewerRole, settings.MinRoleReviewers, settings.RequiredApprovals,
+        int64(settings.ReviewSLA/time.Second), settings.SLAAction)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) SaveCodeOwners(ctx context.Context, teamName, content string) bool {
+    _, err := s.db.ExecContext(ctx,
+        `INSERT INTO team_codeowners (team_name, content, updated_at)
+         VALUES ($1,$2,NOW())
+         ON CONFLICT (team_name) DO UPDATE
+            SET content=EXCLUDED.content,
+                updated_at=EXCLUDED.updated_at`,
+        teamName, content)
+    return err == nil
+}
+
+func (s *PostgresStore) GetCodeOwners(ctx context.Context, teamName string) (string, bool) {
+    var content string
+    err := s.db.QueryRowContext(ctx,
+        `SELECT content FROM team_codeowners WHERE team_name=$1`, teamName).Scan(&content)
+    if err != nil {
+        return "", false
+    }
+    return content, true
+}
+
 func (s *PostgresStore) GetUserByID(ctx context.Context, id string) (*domain.User, bool) {
     u := domain.User{}
+    var skills pq.StringArray
     err := s.db.QueryRowContext(ctx,
-        `SELECT id, username, team_name, is_active FROM users WHERE id=$1`, id).
-        Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive)
+        `SELECT id, username, team_name, is_active, skills, max_open_reviews, role
+         FROM users WHERE id=$1`, id).
+        Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &skills, &u.MaxOpenReviews, &u.Role)
     if err != nil {
         return nil, false
     }
+    u.Skills = skills
     return &u, true
 }
 
 func (s *PostgresStore) SaveUser(ctx context.Context, user *domain.User) {
+    skills := user.Skills
+    if skills == nil {
+        skills = []string{}
+    }
+
     _, _ = s.db.ExecContext(ctx,
-        `INSERT INTO users (id, username, team_name, is_active)
-         VALUES ($1,$2,$3,$4)
+        `INSERT INTO users (id, username, team_name, is_active, skills, max_open_reviews, role)
+         VALUES ($1,$2,$3,$4,$5,$6,$7)
          ON CONFLICT (id) DO UPDATE 
             SET username=EXCLUDED.username,
                 team_name=EXCLUDED.team_name,
-                is_active=EXCLUDED.is_active`,
-        user.ID, user.Username, user.TeamName, user.IsActive)
+                is_active=EXCLUDED.is_active,
+                skills=EXCLUDED.skills,
+                max_open_reviews=EXCLUDED.max_open_reviews,
+                role=EXCLUDED.role`,
+        user.ID, user.Username, user.TeamName, user.IsActive, pq.StringArray(skills), user.MaxOpenReviews, user.Role)
 }
 
 func (s *PostgresStore) SetUserIsActive(ctx context.Context, id string, isActive bool) (*domain.User, bool) {
@@ -114,56 +195,104 @@ func (s *PostgresStore) SetUserIsActive(ctx context.Context, id string, isActive
     return s.GetUserByID(ctx, id)
 }
 
-func (s *PostgresStore) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) bool {
-    reviewers := pr.AssignedReviewers
-    if reviewers == nil {
-        reviewers = []string{}
-    }
+const prColumns = `repository, id, name, author_id, status, reviewers, merged_at, excluded_reviewers,
+    created_at, updated_at, description, labels, source_branch, target_branch, external_url`
 
-    _, err := s.db.ExecContext(ctx,
-        `INSERT INTO pull_requests (id, name, author_id, status, reviewers)
-         VALUES ($1,$2,$3,$4,$5)`,
-        pr.ID, pr.Name, pr.AuthorID, pr.Status, pq.StringArray(reviewers),
-    )
-    return err == nil
+type rowScanner interface {
+    Scan(dest ...any) error
 }
 
-func (s *PostgresStore) GetPullRequestByID(ctx context.Context, id string) (*domain.PullRequest, bool) {
+func scanPullRequest(row rowScanner) (*domain.PullRequest, error) {
     pr := domain.PullRequest{}
-    var reviewers pq.StringArray
+    var reviewers, excluded, labels pq.StringArray
     var mergedAt sql.NullTime
 
-    err := s.db.QueryRowContext(ctx,
-        `SELECT id, name, author_id, status, reviewers, merged_at 
-         FROM pull_requests WHERE id=$1`,
-        id,
-    ).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &reviewers, &mergedAt)
-
+    err := row.Scan(&pr.Repository, &pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &reviewers, &mergedAt, &excluded,
+        &pr.CreatedAt, &pr.UpdatedAt, &pr.Description, &labels, &pr.SourceBranch, &pr.TargetBranch, &pr.ExternalURL)
     if err != nil {
-        return nil, false
+        return nil, err
     }
 
     pr.AssignedReviewers = reviewers
+    pr.ExcludedReviewers = excluded
+    pr.Labels = labels
     if mergedAt.Valid {
         pr.MergedAt = &mergedAt.Time
     }
-    return &pr, true
+    return &pr, nil
+}
+
+func nonNil(list []string) pq.StringArray {
+    if list == nil {
+        return pq.StringArray{}
+    }
+    return pq.StringArray(list)
+}
+
+func (s *PostgresStore) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) bool {
+    _, err := s.db.ExecContext(ctx,
+        `INSERT INTO pull_requests (id, name, author_id, status, reviewers, excluded_reviewers,
+                                    created_at, updated_at, description, labels,
+                                    source_branch, target_branch, external_url, repository)
+         VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`,
+        pr.ID, pr.Name, pr.AuthorID, pr.Status,
+        nonNil(pr.AssignedReviewers), nonNil(pr.ExcludedReviewers),
+        pr.CreatedAt, pr.UpdatedAt, pr.Description, nonNil(pr.Labels),
+        pr.SourceBranch, pr.TargetBranch, pr.ExternalURL, pr.Repository,
+    )
+    return err == nil
+}
+
+func (s *PostgresStore) GetPullRequestByID(ctx context.Context, repo, id string) (*domain.PullRequest, bool) {
+    pr, err := scanPullRequest(s.db.QueryRowContext(ctx,
+        `SELECT `+prColumns+` FROM pull_requests WHERE repository=$1 AND id=$2`, repo, id))
+    if err != nil {
+        return nil, false
+    }
+    return pr, true
 }
 
 func (s *PostgresStore) UpdatePullRequest(ctx context.Context, pr *domain.PullRequest) bool {
     _, err := s.db.ExecContext(ctx,
         `UPDATE pull_requests 
-            SET name=$2, author_id=$3, status=$4, reviewers=$5, merged_at=$6 
-          WHERE id=$1`,
+            SET name=$2, author_id=$3, status=$4, reviewers=$5, merged_at=$6,
+                excluded_reviewers=$7, updated_at=$8, description=$9, labels=$10,
+                source_branch=$11, target_branch=$12, external_url=$13
+          WHERE id=$1 AND repository=$14`,
         pr.ID, pr.Name, pr.AuthorID, pr.Status,
-        pq.StringArray(pr.AssignedReviewers), pr.MergedAt,
+        nonNil(pr.AssignedReviewers), pr.MergedAt, nonNil(pr.ExcludedReviewers),
+        pr.UpdatedAt, pr.Description, nonNil(pr.Labels),
+        pr.SourceBranch, pr.TargetBranch, pr.ExternalURL, pr.Repository,
     )
     return err == nil
 }
 
 func (s *PostgresStore) ListPullRequests(ctx context.Context) []*domain.PullRequest {
     rows, err := s.db.QueryContext(ctx,
-        `SELECT id, name, author_id, status, reviewers, merged_at FROM pull_requests`)
+        `SELECT `+prColumns+` FROM pull_requests ORDER BY repository, id`)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.PullRequest{}
+    for rows.Next() {
+        pr, err := scanPullRequest(rows)
+        if err != nil {
+            continue
+        }
+        list = append(list, pr)
+    }
+    return list
+}
+
+func (s *PostgresStore) ListOpenPullRequestsByTeam(ctx context.Context, teamName string) []*domain.PullRequest {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT `+prColumns+` FROM pull_requests
+          WHERE status = 'OPEN'
+            AND (repository IN (SELECT name FROM repositories WHERE $1 = ANY(owner_teams))
+                 OR (repository = '' AND author_id IN (SELECT id FROM users WHERE team_name = $1)))
+          ORDER BY repository, id`, teamName)
     if err != nil {
         return nil
     }
@@ -171,23 +300,50 @@ func (s *PostgresStore) ListPullRequests(ctx context.Context) []*domain.PullRequ
 
     list := []*domain.PullRequest{}
     for rows.Next() {
-        pr := domain.PullRequest{}
-        var reviewers pq.StringArray
-        var mergedAt sql.NullTime
-        _ = rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &reviewers, &mergedAt)
-        pr.AssignedReviewers = reviewers
-        if mergedAt.Valid {
-            pr.MergedAt = &mergedAt.Time
+        pr, err := scanPullRequest(rows)
+        if err != nil {
+            continue
         }
-        list = append(list, &pr)
+        list = append(list, pr)
     }
     return list
 }
 
+func (s *PostgresStore) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+    res := make(map[string]int, len(userIDs))
+    if len(userIDs) == 0 {
+        return res, nil
+    }
+
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT reviewer, COUNT(*)
+         FROM pull_requests, unnest(reviewers) AS reviewer
+         WHERE status = 'OPEN'
+           AND reviewers && $1
+           AND reviewer = ANY($1)
+         GROUP BY reviewer`,
+        pq.StringArray(userIDs))
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var reviewer string
+        var cnt int
+        if err := rows.Scan(&reviewer, &cnt); err != nil {
+            return nil, err
+        }
+        res[reviewer] = cnt
+    }
+    return res, rows.Err()
+}
+
 func (s *PostgresStore) GetStats(ctx context.Context) (*domain.Stats, error) {
     stats := &domain.Stats{
         ReviewAssignments: make(map[string]int),
         PRStatuses:        make(map[domain.PRStatus]int),
+        DeclineReasons:    make(map[string]int),
     }
 
     rows, err := s.db.QueryContext(ctx,
@@ -227,6 +383,22 @@ func (s *PostgresStore) GetStats(ctx context.Context) (*domain.Stats, error) {
         stats.ReviewAssignments[reviewer] = cnt
     }
 
+    rows3, err := s.db.QueryContext(ctx,
+        `SELECT reason, COUNT(*) FROM review_declines GROUP BY reason`)
+    if err != nil {
+        return nil, err
+    }
+    defer rows3.Close()
+
+    for rows3.Next() {
+        var reason string
+        var cnt int
+        if err := rows3.Scan(&reason, &cnt); err != nil {
+            return nil, err
+        }
+        stats.DeclineReasons[reason] = cnt
+    }
+
     return stats, nil
 }
 
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+    "time"
+
+    "github.com/lib/pq"
+)
+
+func (s *PostgresStore) AddUnavailability(ctx context.Context, u *domain.Unavailability) bool {
+    err := s.db.QueryRowContext(ctx,
+        `INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+         VALUES ($1,$2,$3,$4)
+         RETURNING id`,
+        u.UserID, u.StartsAt, u.EndsAt, u.Reason,
+    ).Scan(&u.ID)
+    return err == nil
+}
+
+func (s *PostgresStore) UpdateUnavailability(ctx context.Context, u *domain.Unavailability) bool {
+    res, err := s.db.ExecContext(ctx,
+        `UPDATE user_unavailability
+            SET starts_at=$2, ends_at=$3, reason=$4
+          WHERE id=$1`,
+        u.ID, u.StartsAt, u.EndsAt, u.Reason)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) GetUnavailability(ctx context.Context, id int64) (*domain.Unavailability, bool) {
+    u := domain.Unavailability{}
+    err := s.db.QueryRowContext(ctx,
+        `SELECT id, user_id, starts_at, ends_at, reason
+         FROM user_unavailability WHERE id=$1`, id).
+        Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason)
+    if err != nil {
+        return nil, false
+    }
+    return &u, true
+}
+
+func (s *PostgresStore) ListUnavailability(ctx context.Context, userID string) []*domain.Unavailability {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT id, user_id, starts_at, ends_at, reason
+         FROM user_unavailability
+         WHERE user_id=$1
+         ORDER BY starts_at`, userID)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.Unavailability{}
+    for rows.Next() {
+        u := domain.Unavailability{}
+        if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason); err != nil {
+            return nil
+        }
+        list = append(list, &u)
+    }
+    return list
+}
+
+func (s *PostgresStore) DeleteUnavailability(ctx context.Context, id int64) bool {
+    res, err := s.db.ExecContext(ctx, `DELETE FROM user_unavailability WHERE id=$1`, id)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) UnavailableUserIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]struct{}, error) {
+    res := make(map[string]struct{})
+    if len(userIDs) == 0 {
+        return res, nil
+    }
+
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT DISTINCT user_id
+         FROM user_unavailability
+         WHERE user_id = ANY($1)
+           AND starts_at <= $2
+           AND ends_at > $2`,
+        pq.StringArray(userIDs), at)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var id string
+        if err := rows.Scan(&id); err != nil {
+            return nil, err
+        }
+        res[id] = struct{}{}
+    }
+    return res, rows.Err()
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+    "backend-trainee-assignment/internal/domain"
+    "context"
+
+    "github.com/lib/pq"
+)
+
+func (s *PostgresStore) AddWebhook(ctx context.Context, w *domain.WebhookSubscription) bool {
+    events := make([]string, 0, len(w.Events))
+    for _, e := range w.Events {
+        events = append(events, string(e))
+    }
+
+    err := s.db.QueryRowContext(ctx,
+        `INSERT INTO webhook_subscriptions (url, events, secret, created_at)
+         VALUES ($1,$2,$3,$4)
+         RETURNING id`,
+        w.URL, pq.StringArray(events), w.Secret, w.CreatedAt,
+    ).Scan(&w.ID)
+    return err == nil
+}
+
+func (s *PostgresStore) DeleteWebhook(ctx context.Context, id int64) bool {
+    res, err := s.db.ExecContext(ctx,
+        `DELETE FROM webhook_subscriptions WHERE id=$1`, id)
+    if err != nil {
+        return false
+    }
+    n, err := res.RowsAffected()
+    return err == nil && n > 0
+}
+
+func (s *PostgresStore) ListWebhooks(ctx context.Context) []*domain.WebhookSubscription {
+    rows, err := s.db.QueryContext(ctx,
+        `SELECT id, url, events, secret, created_at
+         FROM webhook_subscriptions
+         ORDER BY id`)
+    if err != nil {
+        return nil
+    }
+    defer rows.Close()
+
+    list := []*domain.WebhookSubscription{}
+    for rows.Next() {
+        w := domain.WebhookSubscription{}
+        var events pq.StringArray
+        if err := rows.Scan(&w.ID, &w.URL, &events, &w.Secret, &w.CreatedAt); err != nil {
+            return nil
+        }
+        for _, e := range events {
+            w.Events = append(w.Events, domain.PREventType(e))
+        }
+        list = append(list, &w)
+    }
+    return list
+}
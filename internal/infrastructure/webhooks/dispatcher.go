@@ -0,0 +1,214 @@
+package webhooks
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// SubscriptionSource lists the current webhook subscriptions.
+type SubscriptionSource interface {
+	ListWebhooks(ctx context.Context) []*domain.WebhookSubscription
+}
+
+// Dispatcher delivers PR events to matching webhook subscriptions in the
+// background. Failed deliveries are retried with exponential backoff.
+type Dispatcher struct {
+	subs        SubscriptionSource
+	client      *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	logger      *log.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type Option func(*Dispatcher)
+
+// WithRetries sets how many times a delivery is attempted and the delay
+// before the first retry; each further retry waits twice as long.
+func WithRetries(maxAttempts int, baseDelay time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.baseDelay = baseDelay
+	}
+}
+
+func WithHTTPClient(c *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = c
+	}
+}
+
+func WithLogger(l *log.Logger) Option {
+	return func(d *Dispatcher) {
+		d.logger = l
+	}
+}
+
+func NewDispatcher(subs SubscriptionSource, opts ...Option) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		subs:        subs,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 5,
+		baseDelay:   time.Second,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	ID            int64  `json:"id"`
+	Event         string `json:"event"`
+	Repository    string `json:"repository,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+	ActorID       string `json:"actor_id,omitempty"`
+	OldReviewerID string `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	FromStatus    string `json:"from_status,omitempty"`
+	ToStatus      string `json:"to_status,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// Publish queues e for every subscription that wants its type and returns
+// without waiting; subscriptions are looked up in the background too, so
+// the caller's request never waits on the store.
+func (d *Dispatcher) Publish(_ context.Context, e *domain.PREvent) {
+	body, err := json.Marshal(Payload{
+		ID:            e.ID,
+		Event:         string(e.Type),
+		Repository:    e.Repository,
+		PullRequestID: e.PullRequestID,
+		ActorID:       e.ActorID,
+		OldReviewerID: e.OldReviewerID,
+		NewReviewerID: e.NewReviewerID,
+		FromStatus:    string(e.FromStatus),
+		ToStatus:      string(e.ToStatus),
+		Reason:        e.Reason,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for _, sub := range d.subs.ListWebhooks(d.ctx) {
+			if !sub.Wants(e.Type) {
+				continue
+			}
+			d.wg.Add(1)
+			go func(sub *domain.WebhookSubscription) {
+				defer d.wg.Done()
+				d.deliver(sub, e, body)
+			}(sub)
+		}
+	}()
+}
+
+// Close stops pending retries and waits for in-flight requests until ctx
+// expires.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) deliver(sub *domain.WebhookSubscription, e *domain.PREvent, body []byte) {
+	delay := d.baseDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := d.send(sub, e, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= d.maxAttempts {
+			d.logf("webhook %d: giving up on event %d after %d attempts: %v", sub.ID, e.ID, attempt, err)
+			return
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// send makes one delivery attempt and reports whether a failure is worth
+// retrying: network errors, 5xx, 408 and 429 are, other statuses are not.
+func (d *Dispatcher) send(sub *domain.WebhookSubscription, e *domain.PREvent, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(e.Type))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(e.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return d.ctx.Err() == nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) logf(format string, args ...any) {
+	if d.logger != nil {
+		d.logger.Printf(format, args...)
+	}
+}
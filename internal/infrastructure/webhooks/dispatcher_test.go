@@ -0,0 +1,188 @@
+package webhooks
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type staticSubs []*domain.WebhookSubscription
+
+func (s staticSubs) ListWebhooks(context.Context) []*domain.WebhookSubscription {
+	return s
+}
+
+// receiver records deliveries and answers with the queued statuses, then
+// with 200.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	got      []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.got = append(rc.got, r)
+	rc.bodies = append(rc.bodies, body)
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.got)
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*receiver, string) {
+	t.Helper()
+	rc := &receiver{statuses: statuses}
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+	return rc, srv.URL
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func closeDispatcher(t *testing.T, d *Dispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := d.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func testEvent(t domain.PREventType) *domain.PREvent {
+	return &domain.PREvent{
+		ID:            7,
+		Repository:    "org/app",
+		PullRequestID: "42",
+		Type:          t,
+		ToStatus:      domain.StatusMerged,
+		CreatedAt:     time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestDispatcherSignsDelivery(t *testing.T) {
+	rc, url := newReceiver(t)
+	d := NewDispatcher(staticSubs{{ID: 1, URL: url, Secret: "s3cret"}})
+
+	d.Publish(context.Background(), testEvent(domain.EventMerged))
+	waitFor(t, "delivery", func() bool { return rc.count() == 1 })
+	closeDispatcher(t, d)
+
+	req, body := rc.got[0], rc.bodies[0]
+	if got, want := req.Header.Get(SignatureHeader), Sign("s3cret", body); got != want {
+		t.Errorf("signature %q, want %q", got, want)
+	}
+	if got := req.Header.Get(EventHeader); got != string(domain.EventMerged) {
+		t.Errorf("event header %q, want MERGED", got)
+	}
+	if got := req.Header.Get(DeliveryHeader); got != "7" {
+		t.Errorf("delivery header %q, want 7", got)
+	}
+
+	var p Payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if p.Event != "MERGED" || p.Repository != "org/app" || p.PullRequestID != "42" || p.ToStatus != "MERGED" {
+		t.Errorf("unexpected payload %+v", p)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{"a":1}' | openssl dgst -sha256 -hmac key
+	want := "sha256=88a67f24bbcdaed0e6c997404bb79a743baf44c6bab2f4c27328e3009d22e342"
+	if got := Sign("key", []byte(`{"a":1}`)); got != want {
+		t.Fatalf("Sign = %q, want %q", got, want)
+	}
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+	}{
+		{name: "server errors are retried", statuses: []int{500, 503}, attempts: 3},
+		{name: "rate limiting is retried", statuses: []int{429}, attempts: 2},
+		{name: "client errors are not retried", statuses: []int{400}, attempts: 1},
+		{name: "gives up after max attempts", statuses: []int{500, 500, 500, 500}, attempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, url := newReceiver(t, tt.statuses...)
+			d := NewDispatcher(staticSubs{{ID: 1, URL: url}}, WithRetries(3, time.Millisecond))
+
+			d.Publish(context.Background(), testEvent(domain.EventCreated))
+			waitFor(t, "attempts", func() bool { return rc.count() >= tt.attempts })
+			// Backoff is a few milliseconds; a stray retry would show up.
+			time.Sleep(50 * time.Millisecond)
+			closeDispatcher(t, d)
+
+			if got := rc.count(); got != tt.attempts {
+				t.Fatalf("got %d attempts, want %d", got, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestDispatcherFiltersEvents(t *testing.T) {
+	merges, mergesURL := newReceiver(t)
+	all, allURL := newReceiver(t)
+	d := NewDispatcher(staticSubs{
+		{ID: 1, URL: mergesURL, Events: []domain.PREventType{domain.EventMerged}},
+		{ID: 2, URL: allURL},
+	})
+
+	d.Publish(context.Background(), testEvent(domain.EventCreated))
+	d.Publish(context.Background(), testEvent(domain.EventMerged))
+	waitFor(t, "deliveries", func() bool { return all.count() == 2 && merges.count() == 1 })
+	time.Sleep(20 * time.Millisecond)
+	closeDispatcher(t, d)
+
+	if got := merges.count(); got != 1 {
+		t.Fatalf("MERGED-only subscriber got %d deliveries, want 1", got)
+	}
+	if got := merges.got[0].Header.Get(EventHeader); got != string(domain.EventMerged) {
+		t.Fatalf("MERGED-only subscriber got %s", got)
+	}
+}
+
+func TestDispatcherCloseStopsRetries(t *testing.T) {
+	rc, url := newReceiver(t, 500, 500, 500)
+	d := NewDispatcher(staticSubs{{ID: 1, URL: url}}, WithRetries(5, time.Hour))
+
+	d.Publish(context.Background(), testEvent(domain.EventCreated))
+	waitFor(t, "first attempt", func() bool { return rc.count() == 1 })
+	closeDispatcher(t, d)
+
+	if got := rc.count(); got != 1 {
+		t.Fatalf("got %d attempts, want 1", got)
+	}
+}
@@ -0,0 +1,85 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type teamSetCodeOwnersRequest struct {
+	TeamName string `json:"team_name"`
+	Content  string `json:"content"`
+}
+
+type teamCodeOwnersResponse struct {
+	TeamName string `json:"team_name"`
+	Content  string `json:"content"`
+}
+
+func (h *Handler) handleTeamSetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req teamSetCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.TeamName = strings.TrimSpace(req.TeamName)
+	if req.TeamName == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "team_name is required",
+			},
+		})
+		return
+	}
+
+	if err := h.svc.SetCodeOwners(r.Context(), req.TeamName, req.Content); err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, teamCodeOwnersResponse{
+		TeamName: req.TeamName,
+		Content:  req.Content,
+	})
+}
+
+func (h *Handler) handleTeamGetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	teamName := strings.TrimSpace(r.URL.Query().Get("team_name"))
+	if teamName == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "team_name query param is required",
+			},
+		})
+		return
+	}
+
+	content, err := h.svc.GetCodeOwners(r.Context(), teamName)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, teamCodeOwnersResponse{
+		TeamName: teamName,
+		Content:  content,
+	})
+}
@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type exclusionRequest struct {
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason"`
+}
+
+type exclusionDTO struct {
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type exclusionResponse struct {
+	Exclusion exclusionDTO `json:"exclusion"`
+}
+
+type exclusionListResponse struct {
+	Exclusions []exclusionDTO `json:"exclusions"`
+}
+
+func (h *Handler) handleExclusionAdd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	req, ok := decodeExclusionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	e, err := h.svc.AddReviewExclusion(r.Context(), app.ReviewExclusionInput{
+		UserID:      req.UserID,
+		OtherUserID: req.OtherUserID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, exclusionResponse{Exclusion: toExclusionDTO(e)})
+}
+
+func (h *Handler) handleExclusionDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	req, ok := decodeExclusionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteReviewExclusion(r.Context(), req.UserID, req.OtherUserID); err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleExclusionList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	userID := strings.TrimSpace(r.URL.Query().Get("user_id"))
+	list := h.svc.ListReviewExclusions(r.Context(), userID)
+
+	resp := exclusionListResponse{
+		Exclusions: make([]exclusionDTO, 0, len(list)),
+	}
+	for _, e := range list {
+		resp.Exclusions = append(resp.Exclusions, toExclusionDTO(e))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func decodeExclusionRequest(w http.ResponseWriter, r *http.Request) (exclusionRequest, bool) {
+	var req exclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return req, false
+	}
+
+	req.UserID = strings.TrimSpace(req.UserID)
+	req.OtherUserID = strings.TrimSpace(req.OtherUserID)
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if req.UserID == "" || req.OtherUserID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "user_id and other_user_id are required",
+			},
+		})
+		return req, false
+	}
+
+	return req, true
+}
+
+func toExclusionDTO(e *domain.ReviewExclusion) exclusionDTO {
+	return exclusionDTO{
+		UserID:      e.UserID,
+		OtherUserID: e.OtherUserID,
+		Reason:      e.Reason,
+		CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -3,32 +3,114 @@ package httpapi
 import (
 	"backend-trainee-assignment/internal/app"
 	"net/http"
+	"strings"
 )
 
 type Handler struct {
 	svc *app.Service
+
+	githubSecret string
+	gitlabToken  string
+}
+
+type Option func(*Handler)
+
+// WithGitHubSecret enables /integrations/github, verifying deliveries
+// against the webhook secret configured on GitHub.
+func WithGitHubSecret(secret string) Option {
+	return func(h *Handler) {
+		h.githubSecret = secret
+	}
 }
 
-func NewHandler(svc *app.Service) http.Handler {
+// WithGitLabToken enables /integrations/gitlab, checking the secret token
+// configured on GitLab.
+func WithGitLabToken(token string) Option {
+	return func(h *Handler) {
+		h.gitlabToken = token
+	}
+}
+
+func NewHandler(svc *app.Service, opts ...Option) http.Handler {
 	h := &Handler{svc: svc}
+	for _, opt := range opts {
+		opt(h)
+	}
 
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/team/add", h.handleTeamAdd)
 	mux.HandleFunc("/team/get", h.handleTeamGet)
+	mux.HandleFunc("/team/addMember", h.handleTeamAddMember)
 	mux.HandleFunc("/team/deactivate", h.handleTeamDeactivate)
+	mux.HandleFunc("/team/rebalance", h.handleTeamRebalance)
+	mux.HandleFunc("/team/getSettings", h.handleTeamGetSettings)
+	mux.HandleFunc("/team/setSettings", h.handleTeamSetSettings)
+	mux.HandleFunc("/team/setCodeOwners", h.handleTeamSetCodeOwners)
+	mux.HandleFunc("/team/getCodeOwners", h.handleTeamGetCodeOwners)
 
 	mux.HandleFunc("/users/setIsActive", h.handleUserSetIsActive)
 	mux.HandleFunc("/users/getReview", h.handleUserGetReview)
+	mux.HandleFunc("/users/get", h.handleUserGet)
+	mux.HandleFunc("/users/update", h.handleUserUpdate)
+	mux.HandleFunc("/users/addUnavailability", h.handleUserAddUnavailability)
+	mux.HandleFunc("/users/updateUnavailability", h.handleUserUpdateUnavailability)
+	mux.HandleFunc("/users/getUnavailability", h.handleUserGetUnavailability)
+	mux.HandleFunc("/users/deleteUnavailability", h.handleUserDeleteUnavailability)
+
+	mux.HandleFunc("/exclusions/add", h.handleExclusionAdd)
+	mux.HandleFunc("/exclusions/delete", h.handleExclusionDelete)
+	mux.HandleFunc("/exclusions/list", h.handleExclusionList)
+
+	mux.HandleFunc("/webhooks/add", h.handleWebhookAdd)
+	mux.HandleFunc("/webhooks/delete", h.handleWebhookDelete)
+	mux.HandleFunc("/webhooks/list", h.handleWebhookList)
+
+	mux.HandleFunc("/identities/set", h.handleIdentitySet)
+	mux.HandleFunc("/identities/delete", h.handleIdentityDelete)
+	mux.HandleFunc("/identities/list", h.handleIdentityList)
+
+	mux.HandleFunc("/integrations/github", h.handleGitHubWebhook)
+	mux.HandleFunc("/integrations/gitlab", h.handleGitLabWebhook)
+
+	mux.HandleFunc("/repository/add", h.handleRepositoryAdd)
+	mux.HandleFunc("/repository/get", h.handleRepositoryGet)
+	mux.HandleFunc("/repository/list", h.handleRepositoryList)
+	mux.HandleFunc("/repository/setOwners", h.handleRepositorySetOwners)
 
 	mux.HandleFunc("/pullRequest/create", h.handlePullRequestCreate)
 	mux.HandleFunc("/pullRequest/merge", h.handlePullRequestMerge)
+	mux.HandleFunc("/pullRequest/markReady", h.handlePullRequestMarkReady)
+	mux.HandleFunc("/pullRequest/close", h.handlePullRequestClose)
+	mux.HandleFunc("/pullRequest/reopen", h.handlePullRequestReopen)
 	mux.HandleFunc("/pullRequest/reassign", h.handlePullRequestReassign)
+	mux.HandleFunc("/pullRequest/addReviewer", h.handlePullRequestAddReviewer)
+	mux.HandleFunc("/pullRequest/removeReviewer", h.handlePullRequestRemoveReviewer)
+	mux.HandleFunc("/pullRequest/selfAssign", h.handlePullRequestSelfAssign)
+	mux.HandleFunc("/pullRequest/decline", h.handlePullRequestDecline)
+	mux.HandleFunc("/pullRequest/submitReview", h.handlePullRequestSubmitReview)
+	mux.HandleFunc("/pullRequest/getReviews", h.handlePullRequestGetReviews)
+	mux.HandleFunc("/pullRequest/history", h.handlePullRequestHistory)
+	mux.HandleFunc("/pullRequest/list", h.handlePullRequestList)
 
 	mux.HandleFunc("/health", h.handleHealth)
 
 	mux.HandleFunc("/stats", h.handleStats)
 
 
-	return mux
+	return withActor(mux)
+}
+
+// actorHeader names the user making the request.
+const actorHeader = "X-Actor-ID"
+
+// withActor attributes the request to the user named in the X-Actor-ID
+// header, if any.
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actorID := strings.TrimSpace(r.Header.Get(actorHeader)); actorID != "" {
+			r = r.WithContext(app.WithActor(r.Context(), actorID))
+		}
+		next.ServeHTTP(w, r)
+	})
 }
@@ -0,0 +1,90 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	memory "backend-trainee-assignment/internal/infrastructure/persistance/in_memory"
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestHandler serves a fresh in-memory service with team "core" of
+// active users a, b and c, each reviewing alone.
+func newTestHandler(t *testing.T, opts ...Option) http.Handler {
+	t.Helper()
+	h := NewHandler(app.NewService(memory.NewInMemoryStore(), rand.New(rand.NewSource(1))), opts...)
+
+	rec := do(t, h, http.MethodPost, "/team/add", map[string]any{
+		"team_name": "core",
+		"members": []map[string]any{
+			{"user_id": "a", "username": "a", "is_active": true},
+			{"user_id": "b", "username": "b", "is_active": true},
+			{"user_id": "c", "username": "c", "is_active": true},
+		},
+	}, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /team/add: %d %s", rec.Code, rec.Body)
+	}
+	rec = do(t, h, http.MethodPost, "/team/setSettings", map[string]any{"team_name": "core", "reviewers_required": 1}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /team/setSettings: %d %s", rec.Code, rec.Body)
+	}
+	return h
+}
+
+// do sends body, if any, as JSON.
+func do(t *testing.T, h http.Handler, method, path string, body any, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+		t.Fatalf("decoding %q: %v", rec.Body, err)
+	}
+}
+
+func TestActorHeaderIsRecordedInHistory(t *testing.T) {
+	h := newTestHandler(t)
+	actor := http.Header{actorHeader: {"a"}}
+
+	rec := do(t, h, http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "p", "pull_request_name": "p", "author_id": "a",
+	}, actor)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /pullRequest/create: %d %s", rec.Code, rec.Body)
+	}
+
+	rec = do(t, h, http.MethodGet, "/pullRequest/history?pull_request_id=p", nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /pullRequest/history: %d %s", rec.Code, rec.Body)
+	}
+	var resp prHistoryResponse
+	decode(t, rec, &resp)
+	if len(resp.Events) == 0 {
+		t.Fatal("no events recorded")
+	}
+	for _, e := range resp.Events {
+		if e.ActorID != "a" {
+			t.Errorf("event %+v not attributed to a", e)
+		}
+	}
+}
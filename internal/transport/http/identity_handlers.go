@@ -0,0 +1,114 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type identityRequest struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+type identityDTO struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+type identityListResponse struct {
+	Identities []identityDTO `json:"identities"`
+}
+
+func (h *Handler) handleIdentitySet(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeIdentityRequest(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := h.svc.SetIdentity(r.Context(), domain.Provider(req.Provider), req.Login, strings.TrimSpace(req.UserID))
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toIdentityDTO(id))
+}
+
+func (h *Handler) handleIdentityDelete(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeIdentityRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteIdentity(r.Context(), domain.Provider(req.Provider), req.Login); err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleIdentityList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	provider := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("provider")))
+	list, err := h.svc.ListIdentities(r.Context(), domain.Provider(provider))
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := identityListResponse{
+		Identities: make([]identityDTO, 0, len(list)),
+	}
+	for _, id := range list {
+		resp.Identities = append(resp.Identities, toIdentityDTO(id))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func decodeIdentityRequest(w http.ResponseWriter, r *http.Request) (identityRequest, bool) {
+	var req identityRequest
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return req, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return req, false
+	}
+
+	req.Provider = strings.ToLower(strings.TrimSpace(req.Provider))
+	if req.Provider == "" || strings.TrimSpace(req.Login) == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "provider and login are required",
+			},
+		})
+		return req, false
+	}
+	return req, true
+}
+
+func toIdentityDTO(id *domain.ExternalIdentity) identityDTO {
+	return identityDTO{
+		Provider: string(id.Provider),
+		Login:    id.Login,
+		UserID:   id.UserID,
+	}
+}
@@ -0,0 +1,276 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxWebhookBody caps inbound payloads; GitHub's own limit is 25MB but PR
+// events are far smaller.
+const maxWebhookBody = 5 << 20
+
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int64  `json:"number"`
+	PullRequest struct {
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		Draft   bool   `json:"draft"`
+		Merged  bool   `json:"merged"`
+		HTMLURL string `json:"html_url"`
+		User    struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+type gitlabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int64  `json:"iid"`
+		Title          string `json:"title"`
+		Description    string `json:"description"`
+		Action         string `json:"action"`
+		SourceBranch   string `json:"source_branch"`
+		TargetBranch   string `json:"target_branch"`
+		URL            string `json:"url"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Labels []struct {
+		Title string `json:"title"`
+	} `json:"labels"`
+}
+
+type ingestResponse struct {
+	Action string `json:"action"`
+	PR     prDTO  `json:"pr"`
+}
+
+type ingestIgnoredResponse struct {
+	Ignored string `json:"ignored"`
+}
+
+func (h *Handler) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	body, ok := readWebhookBody(w, r)
+	if !ok {
+		return
+	}
+	if h.githubSecret == "" || !validGitHubSignature(h.githubSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{
+			Error: errorBody{
+				Code:    "UNAUTHORIZED",
+				Message: "invalid webhook signature",
+			},
+		})
+		return
+	}
+
+	if event := r.Header.Get("X-GitHub-Event"); event != "pull_request" {
+		writeJSON(w, http.StatusAccepted, ingestIgnoredResponse{Ignored: "event " + event})
+		return
+	}
+
+	var ev githubPullRequestEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	var action app.IngestAction
+	switch ev.Action {
+	case "opened":
+		action = app.IngestOpened
+	case "ready_for_review":
+		action = app.IngestReady
+	case "reopened":
+		action = app.IngestReopened
+	case "closed":
+		action = app.IngestClosed
+		if ev.PullRequest.Merged {
+			action = app.IngestMerged
+		}
+	default:
+		writeJSON(w, http.StatusAccepted, ingestIgnoredResponse{Ignored: "action " + ev.Action})
+		return
+	}
+
+	labels := make([]string, 0, len(ev.PullRequest.Labels))
+	for _, l := range ev.PullRequest.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	h.ingest(w, r, app.IngestPullRequestInput{
+		Provider:     domain.ProviderGitHub,
+		Action:       action,
+		Repository:   ev.Repository.FullName,
+		ID:           strconv.FormatInt(ev.Number, 10),
+		Name:         ev.PullRequest.Title,
+		AuthorLogin:  ev.PullRequest.User.Login,
+		SenderLogin:  ev.Sender.Login,
+		Draft:        ev.PullRequest.Draft,
+		Description:  ev.PullRequest.Body,
+		Labels:       labels,
+		SourceBranch: ev.PullRequest.Head.Ref,
+		TargetBranch: ev.PullRequest.Base.Ref,
+		ExternalURL:  ev.PullRequest.HTMLURL,
+	})
+}
+
+func (h *Handler) handleGitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	body, ok := readWebhookBody(w, r)
+	if !ok {
+		return
+	}
+	token := r.Header.Get("X-Gitlab-Token")
+	if h.gitlabToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.gitlabToken)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{
+			Error: errorBody{
+				Code:    "UNAUTHORIZED",
+				Message: "invalid webhook token",
+			},
+		})
+		return
+	}
+
+	var ev gitlabMergeRequestEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+	if ev.ObjectKind != "merge_request" {
+		writeJSON(w, http.StatusAccepted, ingestIgnoredResponse{Ignored: "event " + ev.ObjectKind})
+		return
+	}
+
+	var action app.IngestAction
+	switch ev.ObjectAttributes.Action {
+	case "open":
+		action = app.IngestOpened
+	case "reopen":
+		action = app.IngestReopened
+	case "close":
+		action = app.IngestClosed
+	case "merge":
+		action = app.IngestMerged
+	default:
+		writeJSON(w, http.StatusAccepted, ingestIgnoredResponse{Ignored: "action " + ev.ObjectAttributes.Action})
+		return
+	}
+
+	labels := make([]string, 0, len(ev.Labels))
+	for _, l := range ev.Labels {
+		labels = append(labels, l.Title)
+	}
+
+	// GitLab only names the user who triggered the event, who for "open" is
+	// the author.
+	h.ingest(w, r, app.IngestPullRequestInput{
+		Provider:     domain.ProviderGitLab,
+		Action:       action,
+		Repository:   ev.Project.PathWithNamespace,
+		ID:           strconv.FormatInt(ev.ObjectAttributes.IID, 10),
+		Name:         ev.ObjectAttributes.Title,
+		AuthorLogin:  ev.User.Username,
+		SenderLogin:  ev.User.Username,
+		Draft:        ev.ObjectAttributes.Draft || ev.ObjectAttributes.WorkInProgress,
+		Description:  ev.ObjectAttributes.Description,
+		Labels:       labels,
+		SourceBranch: ev.ObjectAttributes.SourceBranch,
+		TargetBranch: ev.ObjectAttributes.TargetBranch,
+		ExternalURL:  ev.ObjectAttributes.URL,
+	})
+}
+
+func (h *Handler) ingest(w http.ResponseWriter, r *http.Request, in app.IngestPullRequestInput) {
+	pr, err := h.svc.IngestPullRequest(r.Context(), in)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ingestResponse{
+		Action: string(in.Action),
+		PR:     toPRDTO(pr),
+	})
+}
+
+func readWebhookBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "could not read request body",
+			},
+		})
+		return nil, false
+	}
+	return body, true
+}
+
+// validGitHubSignature checks the "sha256=<hex HMAC>" header GitHub signs
+// deliveries with.
+func validGitHubSignature(secret string, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,175 @@
+package httpapi
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testGitHubSecret = "gh-secret"
+	testGitLabToken  = "gl-token"
+)
+
+// newIntegrationHandler registers repository "org/api", owned by team core,
+// and maps login "Alice" to user a on both providers.
+func newIntegrationHandler(t *testing.T, opts ...Option) http.Handler {
+	t.Helper()
+	h := newTestHandler(t, opts...)
+
+	rec := do(t, h, http.MethodPost, "/repository/add", map[string]any{"repository_name": "org/api", "owner_teams": []string{"core"}}, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /repository/add: %d %s", rec.Code, rec.Body)
+	}
+	for _, provider := range []string{"github", "gitlab"} {
+		rec = do(t, h, http.MethodPost, "/identities/set", map[string]any{"provider": provider, "login": "Alice", "user_id": "a"}, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("POST /identities/set: %d %s", rec.Code, rec.Body)
+		}
+	}
+	return h
+}
+
+func githubSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func postRaw(h http.Handler, path string, body []byte, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+const githubOpened = `{
+	"action": "opened",
+	"number": 7,
+	"pull_request": {"title": "Add search", "user": {"login": "alice"}, "html_url": "https://github.com/org/api/pull/7"},
+	"repository": {"full_name": "org/api"},
+	"sender": {"login": "alice"}
+}`
+
+func TestGitHubWebhookSignature(t *testing.T) {
+	body := []byte(githubOpened)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		event     string
+		want      int
+	}{
+		{"valid", testGitHubSecret, githubSignature(testGitHubSecret, body), "pull_request", http.StatusOK},
+		{"wrong secret", testGitHubSecret, githubSignature("other", body), "pull_request", http.StatusUnauthorized},
+		{"missing signature", testGitHubSecret, "", "pull_request", http.StatusUnauthorized},
+		{"sha1 signature", testGitHubSecret, "sha1=" + githubSignature(testGitHubSecret, body)[len("sha256="):], "pull_request", http.StatusUnauthorized},
+		{"not configured", "", githubSignature("", body), "pull_request", http.StatusUnauthorized},
+		{"other event", testGitHubSecret, githubSignature(testGitHubSecret, body), "push", http.StatusAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newIntegrationHandler(t, WithGitHubSecret(tt.secret))
+			rec := postRaw(h, "/integrations/github", body, http.Header{
+				"X-Hub-Signature-256": {tt.signature},
+				"X-GitHub-Event":      {tt.event},
+			})
+			if rec.Code != tt.want {
+				t.Fatalf("got %d %s, want %d", rec.Code, rec.Body, tt.want)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var resp ingestResponse
+			decode(t, rec, &resp)
+			if resp.Action != "opened" || resp.PR.Repository != "org/api" || resp.PR.PullRequestID != "7" ||
+				resp.PR.AuthorID != "a" || resp.PR.Status != "OPEN" || len(resp.PR.AssignedReviewers) != 1 {
+				t.Fatalf("unexpected response %+v", resp)
+			}
+		})
+	}
+}
+
+func TestGitHubWebhookMergedPullRequest(t *testing.T) {
+	h := newIntegrationHandler(t, WithGitHubSecret(testGitHubSecret))
+	send := func(body string) *httptest.ResponseRecorder {
+		return postRaw(h, "/integrations/github", []byte(body), http.Header{
+			"X-Hub-Signature-256": {githubSignature(testGitHubSecret, []byte(body))},
+			"X-GitHub-Event":      {"pull_request"},
+		})
+	}
+
+	if rec := send(githubOpened); rec.Code != http.StatusOK {
+		t.Fatalf("opened: %d %s", rec.Code, rec.Body)
+	}
+	rec := send(`{"action": "closed", "number": 7, "pull_request": {"merged": true}, "repository": {"full_name": "org/api"}, "sender": {"login": "alice"}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("closed: %d %s", rec.Code, rec.Body)
+	}
+	var resp ingestResponse
+	decode(t, rec, &resp)
+	if resp.Action != "merged" || resp.PR.Status != "MERGED" {
+		t.Fatalf("got %+v, want a merged PR", resp)
+	}
+
+	rec = send(`{"action": "labeled", "number": 7, "repository": {"full_name": "org/api"}}`)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("labeled: got %d %s, want the action ignored", rec.Code, rec.Body)
+	}
+}
+
+func TestGitLabWebhookToken(t *testing.T) {
+	body := []byte(`{
+		"object_kind": "merge_request",
+		"user": {"username": "Alice"},
+		"project": {"path_with_namespace": "org/api"},
+		"object_attributes": {"iid": 3, "title": "Fix login", "action": "open", "work_in_progress": true}
+	}`)
+
+	tests := []struct {
+		name       string
+		configured string
+		token      string
+		want       int
+	}{
+		{"valid", testGitLabToken, testGitLabToken, http.StatusOK},
+		{"wrong token", testGitLabToken, "guess", http.StatusUnauthorized},
+		{"missing token", testGitLabToken, "", http.StatusUnauthorized},
+		{"not configured", "", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newIntegrationHandler(t, WithGitLabToken(tt.configured))
+			rec := postRaw(h, "/integrations/gitlab", body, http.Header{"X-Gitlab-Token": {tt.token}})
+			if rec.Code != tt.want {
+				t.Fatalf("got %d %s, want %d", rec.Code, rec.Body, tt.want)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var resp ingestResponse
+			decode(t, rec, &resp)
+			if resp.PR.PullRequestID != "3" || resp.PR.AuthorID != "a" || resp.PR.Status != "DRAFT" {
+				t.Fatalf("got %+v, want draft MR 3 by a", resp)
+			}
+		})
+	}
+
+	h := newIntegrationHandler(t, WithGitLabToken(testGitLabToken))
+	rec := postRaw(h, "/integrations/gitlab", []byte(`{"object_kind": "push"}`), http.Header{"X-Gitlab-Token": {testGitLabToken}})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("push event: got %d %s, want it ignored", rec.Code, rec.Body)
+	}
+}
@@ -1,7 +1,9 @@
 package httpapi
 
 import (
+	"backend-trainee-assignment/internal/app"
 	"backend-trainee-assignment/internal/domain"
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -9,41 +11,90 @@ import (
 )
 
 type prCreateRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	Repository          string   `json:"repository"`
+	PullRequestID       string   `json:"pull_request_id"`
+	PullRequestName     string   `json:"pull_request_name"`
+	AuthorID            string   `json:"author_id"`
+	ChangedFiles        []string `json:"changed_files"`
+	RequiredSkills      []string `json:"required_skills"`
+	ExcludedReviewerIDs []string `json:"excluded_reviewer_ids"`
+	Draft               bool     `json:"draft"`
+	Description         string   `json:"description"`
+	Labels              []string `json:"labels"`
+	SourceBranch        string   `json:"source_branch"`
+	TargetBranch        string   `json:"target_branch"`
+	ExternalURL         string   `json:"external_url"`
 }
 
 type prMergeRequest struct {
+	Repository    string `json:"repository"`
 	PullRequestID string `json:"pull_request_id"`
 }
 
 type prReassignRequest struct {
+	Repository          string `json:"repository"`
 	PullRequestID       string `json:"pull_request_id"`
 	OldUserID           string `json:"old_user_id"`        
 	LegacyOldReviewerID string `json:"old_reviewer_id"`   
+	// NewUserID makes the swap targeted instead of picking a replacement.
+	NewUserID string `json:"new_user_id"`
+}
+
+type prReviewerRequest struct {
+	Repository    string `json:"repository"`
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+type prDeclineRequest struct {
+	Repository    string `json:"repository"`
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason"`
+}
+
+// prRefDTO identifies a PR in responses that list affected PRs.
+type prRefDTO struct {
+	Repository    string `json:"repository,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
 }
 
 type prDTO struct {
-	PullRequestID   string     `json:"pull_request_id"`
-	PullRequestName string     `json:"pull_request_name"`
-	AuthorID        string     `json:"author_id"`
-	Status          string     `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	MergedAt        string `json:"mergedAt,omitempty"`
+	Repository          string   `json:"repository,omitempty"`
+	PullRequestID       string   `json:"pull_request_id"`
+	PullRequestName     string   `json:"pull_request_name"`
+	AuthorID            string   `json:"author_id"`
+	Status              string   `json:"status"`
+	AssignedReviewers   []string `json:"assigned_reviewers"`
+	MergedAt            string   `json:"mergedAt,omitempty"`
+	ExcludedReviewerIDs []string `json:"excluded_reviewer_ids,omitempty"`
+	CreatedAt           string   `json:"createdAt,omitempty"`
+	UpdatedAt           string   `json:"updatedAt,omitempty"`
+	Description         string   `json:"description,omitempty"`
+	Labels              []string `json:"labels,omitempty"`
+	SourceBranch        string   `json:"source_branch,omitempty"`
+	TargetBranch        string   `json:"target_branch,omitempty"`
+	ExternalURL         string   `json:"external_url,omitempty"`
 }
 
 type prCreateResponse struct {
-	PR prDTO `json:"pr"`
+	PR                    prDTO    `json:"pr"`
+	CodeOwnerReviewers    []string `json:"code_owner_reviewers,omitempty"`
+	FallbackReviewers     []string `json:"fallback_reviewers,omitempty"`
+	OverCapacityReviewers []string `json:"over_capacity_reviewers,omitempty"`
+	UncoveredSkills       []string `json:"uncovered_skills,omitempty"`
+	MissingRoleReviewers  int      `json:"missing_role_reviewers,omitempty"`
 }
 
-type prMergeResponse struct {
+type prResponse struct {
 	PR prDTO `json:"pr"`
 }
 
 type prReassignResponse struct {
-	PR         prDTO  `json:"pr"`
-	ReplacedBy string `json:"replaced_by"`
+	PR                    prDTO    `json:"pr"`
+	ReplacedBy            string   `json:"replaced_by"`
+	FallbackReviewers     []string `json:"fallback_reviewers,omitempty"`
+	OverCapacityReviewers []string `json:"over_capacity_reviewers,omitempty"`
 }
 
 func (h *Handler) handlePullRequestCreate(w http.ResponseWriter, r *http.Request) {
@@ -77,16 +128,38 @@ func (h *Handler) handlePullRequestCreate(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	pr, err := h.svc.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	res, err := h.svc.CreatePullRequest(r.Context(), app.CreatePullRequestInput{
+		Repository:          strings.TrimSpace(req.Repository),
+		ID:                  req.PullRequestID,
+		Name:                req.PullRequestName,
+		AuthorID:            req.AuthorID,
+		ChangedFiles:        trimNonEmpty(req.ChangedFiles),
+		RequiredSkills:      req.RequiredSkills,
+		ExcludedReviewerIDs: trimNonEmpty(req.ExcludedReviewerIDs),
+		Draft:               req.Draft,
+		Description:         strings.TrimSpace(req.Description),
+		Labels:              req.Labels,
+		SourceBranch:        strings.TrimSpace(req.SourceBranch),
+		TargetBranch:        strings.TrimSpace(req.TargetBranch),
+		ExternalURL:         strings.TrimSpace(req.ExternalURL),
+	})
 	if err != nil {
 		writeAppError(w, err)
 		return
 	}
 
-	resp := prCreateResponse{
-		PR: toPRDTO(pr),
+	writeJSON(w, http.StatusCreated, toPRCreateResponse(res))
+}
+
+func toPRCreateResponse(res *app.CreatePullRequestResult) prCreateResponse {
+	return prCreateResponse{
+		PR:                    toPRDTO(res.PR),
+		CodeOwnerReviewers:    res.CodeOwnerReviewerIDs,
+		FallbackReviewers:     res.FallbackReviewerIDs,
+		OverCapacityReviewers: res.OverCapacityReviewerIDs,
+		UncoveredSkills:       res.UncoveredSkills,
+		MissingRoleReviewers:  res.MissingRoleReviewers,
 	}
-	writeJSON(w, http.StatusCreated, resp)
 }
 
 func (h *Handler) handlePullRequestMerge(w http.ResponseWriter, r *http.Request) {
@@ -117,13 +190,13 @@ func (h *Handler) handlePullRequestMerge(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	pr, err := h.svc.MergePullRequest(r.Context(), req.PullRequestID)
+	pr, err := h.svc.MergePullRequest(r.Context(), strings.TrimSpace(req.Repository), req.PullRequestID)
 	if err != nil {
 		writeAppError(w, err)
 		return
 	}
 
-	resp := prMergeResponse{
+	resp := prResponse{
 		PR: toPRDTO(pr),
 	}
 	writeJSON(w, http.StatusOK, resp)
@@ -149,6 +222,7 @@ func (h *Handler) handlePullRequestReassign(w http.ResponseWriter, r *http.Reque
 	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
 	req.OldUserID = strings.TrimSpace(req.OldUserID)
 	req.LegacyOldReviewerID = strings.TrimSpace(req.LegacyOldReviewerID)
+	req.NewUserID = strings.TrimSpace(req.NewUserID)
 
 	if req.OldUserID == "" && req.LegacyOldReviewerID != "" {
 		req.OldUserID = req.LegacyOldReviewerID
@@ -164,30 +238,239 @@ func (h *Handler) handlePullRequestReassign(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	pr, replacedBy, err := h.svc.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	res, err := h.svc.ReassignReviewer(r.Context(), strings.TrimSpace(req.Repository), req.PullRequestID, req.OldUserID, req.NewUserID)
 	if err != nil {
 		writeAppError(w, err)
 		return
 	}
 
 	resp := prReassignResponse{
-		PR:         toPRDTO(pr),
-		ReplacedBy: replacedBy,
+		PR:                    toPRDTO(res.PR),
+		ReplacedBy:            res.ReplacedBy,
+		FallbackReviewers:     res.FallbackReviewerIDs,
+		OverCapacityReviewers: res.OverCapacityReviewerIDs,
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (h *Handler) handlePullRequestAddReviewer(w http.ResponseWriter, r *http.Request) {
+	h.handlePullRequestReviewerChange(w, r, h.svc.AddReviewer)
+}
+
+func (h *Handler) handlePullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	h.handlePullRequestReviewerChange(w, r, h.svc.RemoveReviewer)
+}
+
+// handlePullRequestSelfAssign lets the caller, named by the X-Actor-ID
+// header, volunteer as an extra reviewer. user_id is optional but must name
+// the caller when given.
+func (h *Handler) handlePullRequestSelfAssign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req prReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	actorID := strings.TrimSpace(r.Header.Get(actorHeader))
+	if actorID == "" {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{
+			Error: errorBody{
+				Code:    "UNAUTHORIZED",
+				Message: actorHeader + " header is required",
+			},
+		})
+		return
+	}
+	if userID := strings.TrimSpace(req.UserID); userID != "" && userID != actorID {
+		writeJSON(w, http.StatusForbidden, errorResponse{
+			Error: errorBody{
+				Code:    "FORBIDDEN",
+				Message: "users can only assign themselves",
+			},
+		})
+		return
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	if req.PullRequestID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id is required",
+			},
+		})
+		return
+	}
+
+	pr, err := h.svc.SelfAssignReviewer(r.Context(), strings.TrimSpace(req.Repository), req.PullRequestID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prResponse{PR: toPRDTO(pr)})
+}
+
+// handlePullRequestDecline lets a reviewer, named by the X-Actor-ID header,
+// turn down their own review. user_id must name the caller.
+func (h *Handler) handlePullRequestDecline(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req prDeclineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	actorID := strings.TrimSpace(r.Header.Get(actorHeader))
+	if actorID == "" {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{
+			Error: errorBody{
+				Code:    "UNAUTHORIZED",
+				Message: actorHeader + " header is required",
+			},
+		})
+		return
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	req.UserID = strings.TrimSpace(req.UserID)
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.UserID != "" && req.UserID != actorID {
+		writeJSON(w, http.StatusForbidden, errorResponse{
+			Error: errorBody{
+				Code:    "FORBIDDEN",
+				Message: "users can only decline their own reviews",
+			},
+		})
+		return
+	}
+	if req.PullRequestID == "" || req.UserID == "" || req.Reason == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id, user_id and reason are required",
+			},
+		})
+		return
+	}
+
+	res, err := h.svc.DeclineReview(r.Context(), strings.TrimSpace(req.Repository), req.PullRequestID, req.UserID, req.Reason)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := prReassignResponse{
+		PR:                    toPRDTO(res.PR),
+		ReplacedBy:            res.ReplacedBy,
+		FallbackReviewers:     res.FallbackReviewerIDs,
+		OverCapacityReviewers: res.OverCapacityReviewerIDs,
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type reviewerChangeFunc func(ctx context.Context, repo, prID, userID string) (*domain.PullRequest, error)
+
+func (h *Handler) handlePullRequestReviewerChange(w http.ResponseWriter, r *http.Request, change reviewerChangeFunc) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req prReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.PullRequestID == "" || req.UserID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id and user_id are required",
+			},
+		})
+		return
+	}
+
+	pr, err := change(r.Context(), strings.TrimSpace(req.Repository), req.PullRequestID, req.UserID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prResponse{PR: toPRDTO(pr)})
+}
+
+// prRefIDs keeps only the PR IDs, for the updated_pull_request_ids fields
+// that predate repositories.
+func prRefIDs(refs []app.PullRequestRef) []string {
+	res := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		res = append(res, ref.ID)
+	}
+	return res
+}
+
+func toPRRefDTOs(refs []app.PullRequestRef) []prRefDTO {
+	res := make([]prRefDTO, 0, len(refs))
+	for _, ref := range refs {
+		res = append(res, prRefDTO{Repository: ref.Repository, PullRequestID: ref.ID})
+	}
+	return res
+}
+
 func toPRDTO(pr *domain.PullRequest) prDTO {
     if pr == nil {
         return prDTO{}
     }
 
     dto := prDTO{
-        PullRequestID:     pr.ID,
-        PullRequestName:   pr.Name,
-        AuthorID:          pr.AuthorID,
-        Status:            string(pr.Status),
-        AssignedReviewers: append([]string(nil), pr.AssignedReviewers...),
+        Repository:          pr.Repository,
+        PullRequestID:       pr.ID,
+        PullRequestName:     pr.Name,
+        AuthorID:            pr.AuthorID,
+        Status:              string(pr.Status),
+        AssignedReviewers:   append([]string{}, pr.AssignedReviewers...),
+        ExcludedReviewerIDs: append([]string(nil), pr.ExcludedReviewers...),
+        Description:         pr.Description,
+        Labels:              append([]string(nil), pr.Labels...),
+        SourceBranch:        pr.SourceBranch,
+        TargetBranch:        pr.TargetBranch,
+        ExternalURL:         pr.ExternalURL,
+    }
+
+    if !pr.CreatedAt.IsZero() {
+        dto.CreatedAt = pr.CreatedAt.Format(time.RFC3339)
+    }
+    if !pr.UpdatedAt.IsZero() {
+        dto.UpdatedAt = pr.UpdatedAt.Format(time.RFC3339)
     }
 
     if pr.MergedAt != nil {
@@ -197,3 +480,12 @@ func toPRDTO(pr *domain.PullRequest) prDTO {
     return dto
 }
 
+func trimNonEmpty(list []string) []string {
+	res := make([]string, 0, len(list))
+	for _, v := range list {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
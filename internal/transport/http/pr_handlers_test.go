@@ -0,0 +1,48 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPullRequestDeclineIsBoundToCaller(t *testing.T) {
+	h := newTestHandler(t)
+
+	rec := do(t, h, http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "p", "pull_request_name": "p", "author_id": "a",
+	}, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /pullRequest/create: %d %s", rec.Code, rec.Body)
+	}
+	var created prResponse
+	decode(t, rec, &created)
+	reviewer := created.PR.AssignedReviewers[0]
+	other := "b"
+	if reviewer == "b" {
+		other = "c"
+	}
+
+	body := map[string]any{"pull_request_id": "p", "user_id": reviewer, "reason": "busy"}
+	if rec := do(t, h, http.MethodPost, "/pullRequest/decline", body, nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("without %s: %d %s", actorHeader, rec.Code, rec.Body)
+	}
+	if rec := do(t, h, http.MethodPost, "/pullRequest/decline", body, http.Header{actorHeader: {other}}); rec.Code != http.StatusForbidden {
+		t.Fatalf("declining for someone else: %d %s", rec.Code, rec.Body)
+	}
+
+	rec = do(t, h, http.MethodPost, "/pullRequest/decline", body, http.Header{actorHeader: {reviewer}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /pullRequest/decline: %d %s", rec.Code, rec.Body)
+	}
+	var resp prReassignResponse
+	decode(t, rec, &resp)
+	if resp.ReplacedBy != other {
+		t.Fatalf("replaced_by %q, want %q", resp.ReplacedBy, other)
+	}
+
+	// Nobody is left to take over from other.
+	body["user_id"] = other
+	if rec := do(t, h, http.MethodPost, "/pullRequest/decline", body, http.Header{actorHeader: {other}}); rec.Code != http.StatusConflict {
+		t.Fatalf("declining without candidates: %d %s", rec.Code, rec.Body)
+	}
+}
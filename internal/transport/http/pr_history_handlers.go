@@ -0,0 +1,76 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type prEventDTO struct {
+	ID            int64  `json:"id"`
+	Type          string `json:"type"`
+	ActorID       string `json:"actor_id,omitempty"`
+	OldReviewerID string `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	FromStatus    string `json:"from_status,omitempty"`
+	ToStatus      string `json:"to_status,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type prHistoryResponse struct {
+	Repository    string       `json:"repository,omitempty"`
+	PullRequestID string       `json:"pull_request_id"`
+	Events        []prEventDTO `json:"events"`
+}
+
+func (h *Handler) handlePullRequestHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	repo := strings.TrimSpace(r.URL.Query().Get("repository"))
+	prID := strings.TrimSpace(r.URL.Query().Get("pull_request_id"))
+	if prID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id query param is required",
+			},
+		})
+		return
+	}
+
+	events, err := h.svc.PullRequestHistory(r.Context(), repo, prID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := prHistoryResponse{
+		Repository:    repo,
+		PullRequestID: prID,
+		Events:        make([]prEventDTO, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, toPREventDTO(e))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toPREventDTO(e *domain.PREvent) prEventDTO {
+	return prEventDTO{
+		ID:            e.ID,
+		Type:          string(e.Type),
+		ActorID:       e.ActorID,
+		OldReviewerID: e.OldReviewerID,
+		NewReviewerID: e.NewReviewerID,
+		FromStatus:    string(e.FromStatus),
+		ToStatus:      string(e.ToStatus),
+		Reason:        e.Reason,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,106 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type prMarkReadyRequest struct {
+	Repository     string   `json:"repository"`
+	PullRequestID  string   `json:"pull_request_id"`
+	ChangedFiles   []string `json:"changed_files"`
+	RequiredSkills []string `json:"required_skills"`
+}
+
+func (h *Handler) handlePullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req prMarkReadyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	if req.PullRequestID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id is required",
+			},
+		})
+		return
+	}
+
+	res, err := h.svc.MarkPullRequestReady(r.Context(), app.MarkReadyInput{
+		Repository:     strings.TrimSpace(req.Repository),
+		ID:             req.PullRequestID,
+		ChangedFiles:   trimNonEmpty(req.ChangedFiles),
+		RequiredSkills: req.RequiredSkills,
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPRCreateResponse(res))
+}
+
+func (h *Handler) handlePullRequestClose(w http.ResponseWriter, r *http.Request) {
+	h.handlePullRequestStatusChange(w, r, h.svc.ClosePullRequest)
+}
+
+func (h *Handler) handlePullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	h.handlePullRequestStatusChange(w, r, h.svc.ReopenPullRequest)
+}
+
+type statusChangeFunc func(ctx context.Context, repo, prID string) (*domain.PullRequest, error)
+
+func (h *Handler) handlePullRequestStatusChange(w http.ResponseWriter, r *http.Request, change statusChangeFunc) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req prMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	if req.PullRequestID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id is required",
+			},
+		})
+		return
+	}
+
+	pr, err := change(r.Context(), strings.TrimSpace(req.Repository), req.PullRequestID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prResponse{PR: toPRDTO(pr)})
+}
@@ -0,0 +1,106 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type prListResponse struct {
+	PullRequests []prDTO `json:"pull_requests"`
+	NextCursor   string  `json:"next_cursor,omitempty"`
+}
+
+// handlePullRequestList serves GET /pullRequest/list. Filters: status
+// (comma-separated), author_id, reviewer_id, team_name, created_from and
+// created_to (RFC 3339). Paging: sort (created_at, updated_at), order
+// (desc by default), limit and cursor.
+func (h *Handler) handlePullRequestList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	in, msg := parsePullRequestListQuery(r.URL.Query())
+	if msg != "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: msg,
+			},
+		})
+		return
+	}
+
+	page, err := h.svc.ListPullRequests(r.Context(), in)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := prListResponse{
+		PullRequests: make([]prDTO, 0, len(page.PRs)),
+		NextCursor:   page.NextCursor,
+	}
+	for _, pr := range page.PRs {
+		resp.PullRequests = append(resp.PullRequests, toPRDTO(pr))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parsePullRequestListQuery returns the parsed input or a message
+// describing the first malformed param.
+func parsePullRequestListQuery(q url.Values) (app.ListPullRequestsInput, string) {
+	in := app.ListPullRequestsInput{
+		AuthorID:   strings.TrimSpace(q.Get("author_id")),
+		ReviewerID: strings.TrimSpace(q.Get("reviewer_id")),
+		TeamName:   strings.TrimSpace(q.Get("team_name")),
+		Sort:       domain.PRSortField(strings.ToLower(strings.TrimSpace(q.Get("sort")))),
+		Desc:       true,
+		Cursor:     strings.TrimSpace(q.Get("cursor")),
+	}
+
+	for _, st := range trimNonEmpty(strings.Split(q.Get("status"), ",")) {
+		in.Statuses = append(in.Statuses, domain.PRStatus(strings.ToUpper(st)))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(q.Get("order"))) {
+	case "", "desc":
+	case "asc":
+		in.Desc = false
+	default:
+		return in, "order must be asc or desc"
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"created_from", &in.CreatedFrom},
+		{"created_to", &in.CreatedTo},
+	} {
+		v := strings.TrimSpace(q.Get(p.name))
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return in, p.name + " must be an RFC 3339 timestamp"
+		}
+		*p.dst = t
+	}
+
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return in, "limit must be a positive integer"
+		}
+		in.Limit = limit
+	}
+	return in, ""
+}
@@ -0,0 +1,69 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestPullRequestListFollowsNextCursor(t *testing.T) {
+	h := newTestHandler(t)
+	for _, id := range []string{"p1", "p2", "p3"} {
+		rec := do(t, h, http.MethodPost, "/pullRequest/create", map[string]any{
+			"pull_request_id": id, "pull_request_name": id, "author_id": "a",
+		}, nil)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("POST /pullRequest/create: %d %s", rec.Code, rec.Body)
+		}
+	}
+
+	var got []string
+	cursor := ""
+	for pages := 0; pages < 3; pages++ {
+		rec := do(t, h, http.MethodGet, "/pullRequest/list?order=asc&limit=2&author_id=a&cursor="+url.QueryEscape(cursor), nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /pullRequest/list: %d %s", rec.Code, rec.Body)
+		}
+		var resp prListResponse
+		decode(t, rec, &resp)
+		for _, pr := range resp.PullRequests {
+			got = append(got, pr.PullRequestID)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	if want := []string{"p1", "p2", "p3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// The cursor was issued for ascending order; the default is descending.
+	rec := do(t, h, http.MethodGet, "/pullRequest/list?limit=2&cursor="+url.QueryEscape(cursor), nil, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("cursor with another order: got %d %s, want 400", rec.Code, rec.Body)
+	}
+}
+
+func TestPullRequestListRejectsMalformedQuery(t *testing.T) {
+	h := newTestHandler(t)
+
+	for _, query := range []string{
+		"order=sideways",
+		"limit=0",
+		"limit=ten",
+		"limit=101",
+		"created_from=yesterday",
+		"status=pending",
+		"sort=name",
+		"cursor=garbage",
+	} {
+		t.Run(query, func(t *testing.T) {
+			rec := do(t, h, http.MethodGet, "/pullRequest/list?"+query, nil, nil)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("got %d %s, want 400", rec.Code, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type repositoryRequest struct {
+	RepositoryName string   `json:"repository_name"`
+	OwnerTeams     []string `json:"owner_teams"`
+}
+
+type repositoryDTO struct {
+	RepositoryName string   `json:"repository_name"`
+	OwnerTeams     []string `json:"owner_teams"`
+}
+
+type repositoryResponse struct {
+	Repository repositoryDTO `json:"repository"`
+}
+
+type repositoryListResponse struct {
+	Repositories []repositoryDTO `json:"repositories"`
+}
+
+func (h *Handler) handleRepositoryAdd(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRepositoryRequest(w, r)
+	if !ok {
+		return
+	}
+
+	repo, err := h.svc.CreateRepository(r.Context(), req.RepositoryName, req.OwnerTeams)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, repositoryResponse{Repository: toRepositoryDTO(repo)})
+}
+
+func (h *Handler) handleRepositorySetOwners(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRepositoryRequest(w, r)
+	if !ok {
+		return
+	}
+
+	repo, err := h.svc.SetRepositoryOwners(r.Context(), req.RepositoryName, req.OwnerTeams)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, repositoryResponse{Repository: toRepositoryDTO(repo)})
+}
+
+func (h *Handler) handleRepositoryGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	name := strings.TrimSpace(r.URL.Query().Get("repository_name"))
+	if name == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "repository_name query param is required",
+			},
+		})
+		return
+	}
+
+	repo, err := h.svc.GetRepository(r.Context(), name)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, repositoryResponse{Repository: toRepositoryDTO(repo)})
+}
+
+func (h *Handler) handleRepositoryList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	repos := h.svc.ListRepositories(r.Context())
+	resp := repositoryListResponse{
+		Repositories: make([]repositoryDTO, 0, len(repos)),
+	}
+	for _, repo := range repos {
+		resp.Repositories = append(resp.Repositories, toRepositoryDTO(repo))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// decodeRepositoryRequest reads the body shared by add and setOwners,
+// writing the error response itself when it is invalid.
+func decodeRepositoryRequest(w http.ResponseWriter, r *http.Request) (repositoryRequest, bool) {
+	var req repositoryRequest
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return req, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return req, false
+	}
+
+	req.RepositoryName = strings.TrimSpace(req.RepositoryName)
+	req.OwnerTeams = trimNonEmpty(req.OwnerTeams)
+	if req.RepositoryName == "" || len(req.OwnerTeams) == 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "repository_name and owner_teams are required",
+			},
+		})
+		return req, false
+	}
+	return req, true
+}
+
+func toRepositoryDTO(repo *domain.Repository) repositoryDTO {
+	return repositoryDTO{
+		RepositoryName: repo.Name,
+		OwnerTeams:     append([]string{}, repo.OwnerTeams...),
+	}
+}
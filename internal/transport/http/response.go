@@ -49,13 +49,22 @@ func writeAppError(w http.ResponseWriter, err error) {
 
 func httpStatusFromCode(code app.ErrorCode) int {
 	switch code {
-	case app.ErrorCodeTeamExists:
+	case app.ErrorCodeTeamExists,
+		app.ErrorCodeBadRequest:
 		return http.StatusBadRequest
-	case app.ErrorCodePRExists:
+	case app.ErrorCodePRExists,
+		app.ErrorCodeRepositoryExists,
+		app.ErrorCodeExclusionExists:
 		return http.StatusConflict
 	case app.ErrorCodePRMerged,
+		app.ErrorCodePRNotOpen,
+		app.ErrorCodeInvalidTransition,
+		app.ErrorCodeMergeBlocked,
 		app.ErrorCodeNotAssigned,
-		app.ErrorCodeNoCandidate:
+		app.ErrorCodeAlreadyAssigned,
+		app.ErrorCodeReviewerNotEligible,
+		app.ErrorCodeNoCandidate,
+		app.ErrorCodeAllAtCapacity:
 		return http.StatusConflict
 	case app.ErrorCodeNotFound:
 		return http.StatusNotFound
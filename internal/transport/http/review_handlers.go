@@ -0,0 +1,129 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type submitReviewRequest struct {
+	Repository    string `json:"repository"`
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Decision      string `json:"decision"`
+	Comment       string `json:"comment"`
+}
+
+type reviewDTO struct {
+	Repository    string `json:"repository,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Decision      string `json:"decision"`
+	Comment       string `json:"comment,omitempty"`
+	SubmittedAt   string `json:"submitted_at"`
+}
+
+type reviewResponse struct {
+	Review reviewDTO `json:"review"`
+}
+
+type reviewListResponse struct {
+	Repository    string      `json:"repository,omitempty"`
+	PullRequestID string      `json:"pull_request_id"`
+	Reviews       []reviewDTO `json:"reviews"`
+}
+
+func (h *Handler) handlePullRequestSubmitReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req submitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.PullRequestID = strings.TrimSpace(req.PullRequestID)
+	req.ReviewerID = strings.TrimSpace(req.ReviewerID)
+	req.Comment = strings.TrimSpace(req.Comment)
+	if req.PullRequestID == "" || req.ReviewerID == "" || strings.TrimSpace(req.Decision) == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id, reviewer_id and decision are required",
+			},
+		})
+		return
+	}
+
+	review, err := h.svc.SubmitReview(r.Context(), app.SubmitReviewInput{
+		Repository:    strings.TrimSpace(req.Repository),
+		PullRequestID: req.PullRequestID,
+		ReviewerID:    req.ReviewerID,
+		Decision:      domain.ReviewDecision(req.Decision),
+		Comment:       req.Comment,
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reviewResponse{Review: toReviewDTO(review)})
+}
+
+func (h *Handler) handlePullRequestGetReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	repo := strings.TrimSpace(r.URL.Query().Get("repository"))
+	prID := strings.TrimSpace(r.URL.Query().Get("pull_request_id"))
+	if prID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "pull_request_id query param is required",
+			},
+		})
+		return
+	}
+
+	reviews, err := h.svc.ListReviews(r.Context(), repo, prID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := reviewListResponse{
+		Repository:    repo,
+		PullRequestID: prID,
+		Reviews:       make([]reviewDTO, 0, len(reviews)),
+	}
+	for _, rv := range reviews {
+		resp.Reviews = append(resp.Reviews, toReviewDTO(rv))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toReviewDTO(r *domain.Review) reviewDTO {
+	return reviewDTO{
+		Repository:    r.Repository,
+		PullRequestID: r.PullRequestID,
+		ReviewerID:    r.ReviewerID,
+		Decision:      string(r.Decision),
+		Comment:       r.Comment,
+		SubmittedAt:   r.SubmittedAt.Format(time.RFC3339),
+	}
+}
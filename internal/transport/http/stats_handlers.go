@@ -7,6 +7,7 @@ import (
 type statsResponse struct {
     ReviewAssignments map[string]int `json:"review_assignments"`
     PRStatuses        map[string]int `json:"pr_statuses"`
+    DeclineReasons    map[string]int `json:"decline_reasons"`
 }
 
 func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
@@ -34,6 +35,7 @@ func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
     resp := statsResponse{
         ReviewAssignments: stats.ReviewAssignments,
         PRStatuses:        statuses,
+        DeclineReasons:    stats.DeclineReasons,
     }
 
     writeJSON(w, http.StatusOK, resp)
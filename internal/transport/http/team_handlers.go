@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -11,6 +12,7 @@ type teamMemberDTO struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role"`
 }
 
 type teamAddRequest struct {
@@ -23,14 +25,36 @@ type teamResponse struct {
 	Members  []teamMemberDTO `json:"members"`
 }
 
+type teamAddMemberRequest struct {
+	TeamName string `json:"team_name"`
+	teamMemberDTO
+}
+
+type teamAddMemberResponse struct {
+	User                  userDTO    `json:"user"`
+	UpdatedPullRequestIDs []string   `json:"updated_pull_request_ids"`
+	UpdatedPullRequests   []prRefDTO `json:"updated_pull_requests"`
+}
+
 type teamDeactivateRequest struct {
     TeamName string `json:"team_name"`
+    DryRun   bool   `json:"dry_run"`
 }
 
 type teamDeactivateResponse struct {
-    TeamName              string   `json:"team_name"`
-    DeactivatedUserIDs    []string `json:"deactivated_user_ids"`
-    UpdatedPullRequestIDs []string `json:"updated_pull_request_ids"`
+    TeamName              string              `json:"team_name"`
+    DryRun                bool                `json:"dry_run"`
+    DeactivatedUserIDs    []string            `json:"deactivated_user_ids"`
+    UpdatedPullRequestIDs []string            `json:"updated_pull_request_ids"`
+    UpdatedPullRequests   []prRefDTO          `json:"updated_pull_requests"`
+    Changes               []reviewerChangeDTO `json:"changes"`
+}
+
+type reviewerChangeDTO struct {
+    Repository     string   `json:"repository,omitempty"`
+    PullRequestID  string   `json:"pull_request_id"`
+    OldReviewerIDs []string `json:"old_reviewers"`
+    NewReviewerIDs []string `json:"new_reviewers"`
 }
 
 
@@ -79,6 +103,7 @@ func (h *Handler) handleTeamAdd(w http.ResponseWriter, r *http.Request) {
 			UserID:   m.UserID,
 			Username: m.Username,
 			IsActive: m.IsActive,
+			Role:     domain.Role(strings.ToLower(strings.TrimSpace(m.Role))),
 		})
 	}
 
@@ -97,6 +122,7 @@ func (h *Handler) handleTeamAdd(w http.ResponseWriter, r *http.Request) {
 			UserID:   u.ID,
 			Username: u.Username,
 			IsActive: u.IsActive,
+			Role:     string(u.Role),
 		})
 	}
 
@@ -105,6 +131,54 @@ func (h *Handler) handleTeamAdd(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) handleTeamAddMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req teamAddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.TeamName = strings.TrimSpace(req.TeamName)
+	req.UserID = strings.TrimSpace(req.UserID)
+	req.Username = strings.TrimSpace(req.Username)
+	if req.TeamName == "" || req.UserID == "" || req.Username == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "team_name, user_id and username are required",
+			},
+		})
+		return
+	}
+
+	res, err := h.svc.AddTeamMember(r.Context(), req.TeamName, app.TeamMemberInput{
+		UserID:   req.UserID,
+		Username: req.Username,
+		IsActive: req.IsActive,
+		Role:     domain.Role(strings.ToLower(strings.TrimSpace(req.Role))),
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, teamAddMemberResponse{
+		User:                  toUserDTO(res.User),
+		UpdatedPullRequestIDs: prRefIDs(res.UpdatedPullRequests),
+		UpdatedPullRequests:   toPRRefDTOs(res.UpdatedPullRequests),
+	})
+}
+
 func (h *Handler) handleTeamGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		methodNotAllowed(w, http.MethodGet)
@@ -137,6 +211,7 @@ func (h *Handler) handleTeamGet(w http.ResponseWriter, r *http.Request) {
 			UserID:   u.ID,
 			Username: u.Username,
 			IsActive: u.IsActive,
+			Role:     string(u.Role),
 		})
 	}
 
@@ -171,7 +246,7 @@ func (h *Handler) handleTeamDeactivate(w http.ResponseWriter, r *http.Request) {
         return
     }
 
-    res, err := h.svc.DeactivateTeamUsersAndReassignOpenPRs(r.Context(), req.TeamName)
+    res, err := h.svc.DeactivateTeamUsersAndReassignOpenPRs(r.Context(), req.TeamName, req.DryRun)
     if err != nil {
         writeAppError(w, err)
         return
@@ -179,9 +254,25 @@ func (h *Handler) handleTeamDeactivate(w http.ResponseWriter, r *http.Request) {
 
     resp := teamDeactivateResponse{
         TeamName:              res.TeamName,
+        DryRun:                res.DryRun,
         DeactivatedUserIDs:    append([]string(nil), res.DeactivatedUserIDs...),
-        UpdatedPullRequestIDs: append([]string(nil), res.UpdatedPullRequestIDs...),
+        UpdatedPullRequestIDs: prRefIDs(res.UpdatedPullRequests),
+        UpdatedPullRequests:   toPRRefDTOs(res.UpdatedPullRequests),
+        Changes:               toReviewerChangeDTOs(res.Changes),
     }
 
     writeJSON(w, http.StatusOK, resp)
 }
+
+func toReviewerChangeDTOs(changes []app.ReviewerChange) []reviewerChangeDTO {
+    res := make([]reviewerChangeDTO, 0, len(changes))
+    for _, c := range changes {
+        res = append(res, reviewerChangeDTO{
+            Repository:     c.Repository,
+            PullRequestID:  c.PullRequestID,
+            OldReviewerIDs: append([]string{}, c.OldReviewerIDs...),
+            NewReviewerIDs: append([]string{}, c.NewReviewerIDs...),
+        })
+    }
+    return res
+}
@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTeamDeactivateReportsUpdatedPullRequests(t *testing.T) {
+	h := newTestHandler(t)
+	rec := do(t, h, http.MethodPost, "/team/add", map[string]any{
+		"team_name": "ops",
+		"members":   []map[string]any{{"user_id": "x", "username": "x", "is_active": true}},
+	}, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /team/add: %d %s", rec.Code, rec.Body)
+	}
+	rec = do(t, h, http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "p", "pull_request_name": "p", "author_id": "x",
+	}, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /pullRequest/create: %d %s", rec.Code, rec.Body)
+	}
+	rec = do(t, h, http.MethodPost, "/team/addMember", map[string]any{
+		"team_name": "ops", "user_id": "y", "username": "y", "is_active": true,
+	}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /team/addMember: %d %s", rec.Code, rec.Body)
+	}
+
+	rec = do(t, h, http.MethodPost, "/team/deactivate", map[string]any{"team_name": "ops", "dry_run": true}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /team/deactivate: %d %s", rec.Code, rec.Body)
+	}
+	var resp map[string]any
+	decode(t, rec, &resp)
+	if got, want := resp["updated_pull_request_ids"], []any{"p"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("updated_pull_request_ids %v, want %v", got, want)
+	}
+	if got, want := resp["updated_pull_requests"], []any{map[string]any{"pull_request_id": "p"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("updated_pull_requests %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type teamRebalanceRequest struct {
+	TeamName string `json:"team_name"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+type reviewerMoveDTO struct {
+	Repository    string `json:"repository,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
+
+type teamRebalanceResponse struct {
+	TeamName string            `json:"team_name"`
+	DryRun   bool              `json:"dry_run"`
+	Moves    []reviewerMoveDTO `json:"moves"`
+}
+
+func (h *Handler) handleTeamRebalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req teamRebalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.TeamName = strings.TrimSpace(req.TeamName)
+	if req.TeamName == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "team_name is required",
+			},
+		})
+		return
+	}
+
+	res, err := h.svc.RebalanceTeam(r.Context(), req.TeamName, req.DryRun)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := teamRebalanceResponse{
+		TeamName: res.TeamName,
+		DryRun:   res.DryRun,
+		Moves:    make([]reviewerMoveDTO, 0, len(res.Moves)),
+	}
+	for _, m := range res.Moves {
+		resp.Moves = append(resp.Moves, reviewerMoveDTO{
+			Repository:    m.Repository,
+			PullRequestID: m.PullRequestID,
+			FromUserID:    m.FromUserID,
+			ToUserID:      m.ToUserID,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,161 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type teamSetSettingsRequest struct {
+	TeamName              string    `json:"team_name"`
+	ReviewersRequired     *int      `json:"reviewers_required"`
+	FallbackTeams         *[]string `json:"fallback_teams"`
+	DefaultMaxOpenReviews *int      `json:"default_max_open_reviews"`
+	AllowOverCapacity     *bool     `json:"allow_over_capacity"`
+	MinReviewerRole       *string   `json:"min_reviewer_role"`
+	MinRoleReviewers      *int      `json:"min_role_reviewers"`
+	RequiredApprovals     *int      `json:"required_approvals"`
+	ReviewSLASeconds      *int64    `json:"review_sla_seconds"`
+	SLAAction             *string   `json:"sla_action"`
+}
+
+type teamSettingsDTO struct {
+	ReviewersRequired     int      `json:"reviewers_required"`
+	FallbackTeams         []string `json:"fallback_teams"`
+	DefaultMaxOpenReviews int      `json:"default_max_open_reviews"`
+	AllowOverCapacity     bool     `json:"allow_over_capacity"`
+	MinReviewerRole       string   `json:"min_reviewer_role"`
+	MinRoleReviewers      int      `json:"min_role_reviewers"`
+	RequiredApprovals     int      `json:"required_approvals"`
+	ReviewSLASeconds      int64    `json:"review_sla_seconds"`
+	SLAAction             string   `json:"sla_action"`
+}
+
+type teamSettingsResponse struct {
+	TeamName string          `json:"team_name"`
+	Settings teamSettingsDTO `json:"settings"`
+}
+
+func (h *Handler) handleTeamGetSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	teamName := strings.TrimSpace(r.URL.Query().Get("team_name"))
+	if teamName == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "team_name query param is required",
+			},
+		})
+		return
+	}
+
+	settings, err := h.svc.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, teamSettingsResponse{
+		TeamName: teamName,
+		Settings: toTeamSettingsDTO(settings),
+	})
+}
+
+func (h *Handler) handleTeamSetSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req teamSetSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.TeamName = strings.TrimSpace(req.TeamName)
+	if req.TeamName == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "team_name is required",
+			},
+		})
+		return
+	}
+
+	if req.FallbackTeams != nil {
+		fallbackTeams := make([]string, 0, len(*req.FallbackTeams))
+		for _, name := range *req.FallbackTeams {
+			if name = strings.TrimSpace(name); name != "" {
+				fallbackTeams = append(fallbackTeams, name)
+			}
+		}
+		req.FallbackTeams = &fallbackTeams
+	}
+
+	var minRole *domain.Role
+	if req.MinReviewerRole != nil {
+		v := domain.Role(strings.ToLower(strings.TrimSpace(*req.MinReviewerRole)))
+		minRole = &v
+	}
+
+	var reviewSLA *time.Duration
+	if req.ReviewSLASeconds != nil {
+		v := time.Duration(*req.ReviewSLASeconds) * time.Second
+		reviewSLA = &v
+	}
+
+	var slaAction *domain.SLAAction
+	if req.SLAAction != nil {
+		v := domain.SLAAction(strings.ToLower(strings.TrimSpace(*req.SLAAction)))
+		slaAction = &v
+	}
+
+	settings, err := h.svc.UpdateTeamSettings(r.Context(), req.TeamName, app.TeamSettingsInput{
+		ReviewersRequired:     req.ReviewersRequired,
+		FallbackTeams:         req.FallbackTeams,
+		DefaultMaxOpenReviews: req.DefaultMaxOpenReviews,
+		AllowOverCapacity:     req.AllowOverCapacity,
+		MinReviewerRole:       minRole,
+		MinRoleReviewers:      req.MinRoleReviewers,
+		RequiredApprovals:     req.RequiredApprovals,
+		ReviewSLA:             reviewSLA,
+		SLAAction:             slaAction,
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, teamSettingsResponse{
+		TeamName: req.TeamName,
+		Settings: toTeamSettingsDTO(settings),
+	})
+}
+
+func toTeamSettingsDTO(settings *domain.TeamSettings) teamSettingsDTO {
+	return teamSettingsDTO{
+		ReviewersRequired:     settings.ReviewersRequired,
+		FallbackTeams:         append([]string{}, settings.FallbackTeams...),
+		DefaultMaxOpenReviews: settings.DefaultMaxOpenReviews,
+		AllowOverCapacity:     settings.AllowOverCapacity,
+		MinReviewerRole:       string(settings.MinReviewerRole),
+		MinRoleReviewers:      settings.MinRoleReviewers,
+		RequiredApprovals:     settings.RequiredApprovals,
+		ReviewSLASeconds:      int64(settings.ReviewSLA / time.Second),
+		SLAAction:             string(settings.SLAAction),
+	}
+}
@@ -0,0 +1,210 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type unavailabilityAddRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type unavailabilityUpdateRequest struct {
+	ID       int64     `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type unavailabilityDeleteRequest struct {
+	ID int64 `json:"id"`
+}
+
+type unavailabilityDTO struct {
+	ID       int64  `json:"id"`
+	UserID   string `json:"user_id"`
+	StartsAt string `json:"starts_at"`
+	EndsAt   string `json:"ends_at"`
+	Reason   string `json:"reason"`
+}
+
+type unavailabilityResponse struct {
+	Unavailability unavailabilityDTO `json:"unavailability"`
+}
+
+type unavailabilityListResponse struct {
+	UserID         string              `json:"user_id"`
+	Unavailability []unavailabilityDTO `json:"unavailability"`
+}
+
+func (h *Handler) handleUserAddUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req unavailabilityAddRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.UserID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "user_id is required",
+			},
+		})
+		return
+	}
+
+	u, err := h.svc.AddUnavailability(r.Context(), app.UnavailabilityInput{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, unavailabilityResponse{
+		Unavailability: toUnavailabilityDTO(u),
+	})
+}
+
+func (h *Handler) handleUserUpdateUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req unavailabilityUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	if req.ID <= 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "id is required",
+			},
+		})
+		return
+	}
+
+	u, err := h.svc.UpdateUnavailability(r.Context(), req.ID, app.UnavailabilityInput{
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, unavailabilityResponse{
+		Unavailability: toUnavailabilityDTO(u),
+	})
+}
+
+func (h *Handler) handleUserGetUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	userID := strings.TrimSpace(r.URL.Query().Get("user_id"))
+	if userID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "user_id query param is required",
+			},
+		})
+		return
+	}
+
+	list, err := h.svc.ListUnavailability(r.Context(), userID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	resp := unavailabilityListResponse{
+		UserID:         userID,
+		Unavailability: make([]unavailabilityDTO, 0, len(list)),
+	}
+	for _, u := range list {
+		resp.Unavailability = append(resp.Unavailability, toUnavailabilityDTO(u))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) handleUserDeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req unavailabilityDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	if req.ID <= 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "id is required",
+			},
+		})
+		return
+	}
+
+	if err := h.svc.DeleteUnavailability(r.Context(), req.ID); err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toUnavailabilityDTO(u *domain.Unavailability) unavailabilityDTO {
+	return unavailabilityDTO{
+		ID:       u.ID,
+		UserID:   u.UserID,
+		StartsAt: u.StartsAt.Format(time.RFC3339),
+		EndsAt:   u.EndsAt.Format(time.RFC3339),
+		Reason:   u.Reason,
+	}
+}
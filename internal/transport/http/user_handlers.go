@@ -1,30 +1,50 @@
 package httpapi
 
 import (
+	"backend-trainee-assignment/internal/app"
+	"backend-trainee-assignment/internal/domain"
 	"encoding/json"
 	"net/http"
 	"strings"
-
-	
 )
 
 type setIsActiveRequest struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
+	// ReassignOpenReviews moves a deactivated user's OPEN reviews to others.
+	ReassignOpenReviews bool `json:"reassign_open_reviews"`
+}
+
+type userUpdateRequest struct {
+	UserID string    `json:"user_id"`
+	Skills *[]string `json:"skills"`
+	// MaxOpenReviews of 0 drops the personal limit in favour of the team default.
+	MaxOpenReviews *int    `json:"max_open_reviews"`
+	Role           *string `json:"role"`
 }
 
 type userDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	TeamName       string   `json:"team_name"`
+	IsActive       bool     `json:"is_active"`
+	Skills         []string `json:"skills"`
+	MaxOpenReviews int      `json:"max_open_reviews"`
+	Role           string   `json:"role"`
 }
 
 type setIsActiveResponse struct {
+	User                  userDTO    `json:"user"`
+	UpdatedPullRequestIDs []string   `json:"updated_pull_request_ids"`
+	UpdatedPullRequests   []prRefDTO `json:"updated_pull_requests"`
+}
+
+type userResponse struct {
 	User userDTO `json:"user"`
 }
 
 type pullRequestShortDTO struct {
+	Repository      string `json:"repository,omitempty"`
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
@@ -64,23 +84,105 @@ func (h *Handler) handleUserSetIsActive(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	user, err := h.svc.SetUserIsActive(r.Context(), req.UserID, req.IsActive)
+	res, err := h.svc.SetUserIsActive(r.Context(), req.UserID, req.IsActive, req.ReassignOpenReviews)
 	if err != nil {
 		writeAppError(w, err)
 		return
 	}
 
 	resp := setIsActiveResponse{
-		User: userDTO{
-			UserID:   user.ID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
-		},
+		User:                  toUserDTO(res.User),
+		UpdatedPullRequestIDs: prRefIDs(res.UpdatedPullRequests),
+		UpdatedPullRequests:   toPRRefDTOs(res.UpdatedPullRequests),
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (h *Handler) handleUserGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	userID := strings.TrimSpace(r.URL.Query().Get("user_id"))
+	if userID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "user_id query param is required",
+			},
+		})
+		return
+	}
+
+	user, err := h.svc.GetUser(r.Context(), userID)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userResponse{User: toUserDTO(user)})
+}
+
+func (h *Handler) handleUserUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req userUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.UserID == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "user_id is required",
+			},
+		})
+		return
+	}
+
+	var role *domain.Role
+	if req.Role != nil {
+		v := domain.Role(strings.ToLower(strings.TrimSpace(*req.Role)))
+		role = &v
+	}
+
+	user, err := h.svc.UpdateUser(r.Context(), req.UserID, app.UserUpdateInput{
+		Skills:         req.Skills,
+		MaxOpenReviews: req.MaxOpenReviews,
+		Role:           role,
+	})
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, userResponse{User: toUserDTO(user)})
+}
+
+func toUserDTO(user *domain.User) userDTO {
+	return userDTO{
+		UserID:         user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		Skills:         append([]string{}, user.Skills...),
+		MaxOpenReviews: user.MaxOpenReviews,
+		Role:           string(user.Role),
+	}
+}
+
 func (h *Handler) handleUserGetReview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		methodNotAllowed(w, http.MethodGet)
@@ -107,6 +209,7 @@ func (h *Handler) handleUserGetReview(w http.ResponseWriter, r *http.Request) {
 
 	for _, pr := range prs {
 		resp.PullRequests = append(resp.PullRequests, pullRequestShortDTO{
+			Repository:      pr.Repository,
 			PullRequestID:   pr.ID,
 			PullRequestName: pr.Name,
 			AuthorID:        pr.AuthorID,
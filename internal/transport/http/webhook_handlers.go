@@ -0,0 +1,145 @@
+package httpapi
+
+import (
+	"backend-trainee-assignment/internal/domain"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type webhookAddRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+type webhookDeleteRequest struct {
+	ID int64 `json:"id"`
+}
+
+type webhookDTO struct {
+	ID        int64    `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret,omitempty"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type webhookResponse struct {
+	Webhook webhookDTO `json:"webhook"`
+}
+
+type webhookListResponse struct {
+	Webhooks []webhookDTO `json:"webhooks"`
+}
+
+func (h *Handler) handleWebhookAdd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req webhookAddRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	if req.URL == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "url is required",
+			},
+		})
+		return
+	}
+
+	events := make([]domain.PREventType, 0, len(req.Events))
+	for _, e := range trimNonEmpty(req.Events) {
+		events = append(events, domain.PREventType(strings.ToUpper(e)))
+	}
+
+	sub, err := h.svc.CreateWebhook(r.Context(), req.URL, events, req.Secret)
+	if err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	// The secret is only ever shown on creation.
+	dto := toWebhookDTO(sub)
+	dto.Secret = sub.Secret
+	writeJSON(w, http.StatusCreated, webhookResponse{Webhook: dto})
+}
+
+func (h *Handler) handleWebhookDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+
+	var req webhookDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "invalid JSON",
+			},
+		})
+		return
+	}
+
+	if req.ID <= 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error: errorBody{
+				Code:    "BAD_REQUEST",
+				Message: "id is required",
+			},
+		})
+		return
+	}
+
+	if err := h.svc.DeleteWebhook(r.Context(), req.ID); err != nil {
+		writeAppError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleWebhookList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, http.MethodGet)
+		return
+	}
+
+	list := h.svc.ListWebhooks(r.Context())
+	resp := webhookListResponse{
+		Webhooks: make([]webhookDTO, 0, len(list)),
+	}
+	for _, sub := range list {
+		resp.Webhooks = append(resp.Webhooks, toWebhookDTO(sub))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toWebhookDTO(sub *domain.WebhookSubscription) webhookDTO {
+	events := make([]string, 0, len(sub.Events))
+	for _, e := range sub.Events {
+		events = append(events, string(e))
+	}
+	return webhookDTO{
+		ID:        sub.ID,
+		URL:       sub.URL,
+		Events:    events,
+		CreatedAt: sub.CreatedAt.Format(time.RFC3339),
+	}
+}
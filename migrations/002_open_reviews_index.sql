@@ -0,0 +1,3 @@
+CREATE INDEX pull_requests_open_reviewers_idx
+    ON pull_requests USING GIN (reviewers)
+    WHERE status = 'OPEN';
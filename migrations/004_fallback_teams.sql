@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    ADD COLUMN fallback_teams TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+CREATE TABLE team_codeowners (
+    team_name TEXT PRIMARY KEY REFERENCES teams(name),
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INT NOT NULL DEFAULT 0
+        CHECK (max_open_reviews >= 0);
+
+ALTER TABLE teams
+    ADD COLUMN default_max_open_reviews INT NOT NULL DEFAULT 0
+        CHECK (default_max_open_reviews >= 0),
+    ADD COLUMN allow_over_capacity BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+CREATE TABLE review_exclusions (
+    user_a TEXT NOT NULL REFERENCES users(id),
+    user_b TEXT NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_a, user_b),
+    CHECK (user_a < user_b)
+);
+
+CREATE INDEX review_exclusions_user_b_idx ON review_exclusions (user_b);
+
+ALTER TABLE pull_requests
+    ADD COLUMN excluded_reviewers TEXT[] NOT NULL DEFAULT '{}';
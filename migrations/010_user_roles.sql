@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'middle'
+        CHECK (role IN ('junior', 'middle', 'senior', 'lead'));
+
+ALTER TABLE teams
+    ADD COLUMN min_reviewer_role TEXT NOT NULL DEFAULT 'senior'
+        CHECK (min_reviewer_role IN ('junior', 'middle', 'senior', 'lead')),
+    ADD COLUMN min_role_reviewers INT NOT NULL DEFAULT 0
+        CHECK (min_role_reviewers >= 0);
@@ -0,0 +1,13 @@
+CREATE TABLE review_decisions (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id),
+    reviewer_id TEXT NOT NULL REFERENCES users(id),
+    decision TEXT NOT NULL
+        CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    comment TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+
+ALTER TABLE teams
+    ADD COLUMN required_approvals INT NOT NULL DEFAULT 0
+        CHECK (required_approvals >= 0);
@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN source_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN target_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN external_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,26 @@
+CREATE TABLE repositories (
+    name TEXT PRIMARY KEY,
+    owner_teams TEXT[] NOT NULL
+);
+
+ALTER TABLE review_declines DROP CONSTRAINT review_declines_pull_request_id_fkey;
+ALTER TABLE review_decisions DROP CONSTRAINT review_decisions_pull_request_id_fkey;
+
+-- PR IDs are unique per repository; '' is the legacy global namespace.
+ALTER TABLE pull_requests
+    ADD COLUMN repository TEXT NOT NULL DEFAULT '',
+    DROP CONSTRAINT pull_requests_pkey,
+    ADD PRIMARY KEY (repository, id);
+
+ALTER TABLE review_declines
+    ADD COLUMN repository TEXT NOT NULL DEFAULT '',
+    ADD FOREIGN KEY (repository, pull_request_id) REFERENCES pull_requests(repository, id);
+
+DROP INDEX review_declines_pr_idx;
+CREATE INDEX review_declines_pr_idx ON review_declines (repository, pull_request_id);
+
+ALTER TABLE review_decisions
+    ADD COLUMN repository TEXT NOT NULL DEFAULT '',
+    DROP CONSTRAINT review_decisions_pkey,
+    ADD PRIMARY KEY (repository, pull_request_id, reviewer_id),
+    ADD FOREIGN KEY (repository, pull_request_id) REFERENCES pull_requests(repository, id);
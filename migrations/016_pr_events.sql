@@ -0,0 +1,16 @@
+CREATE TABLE pr_events (
+    id BIGSERIAL PRIMARY KEY,
+    repository TEXT NOT NULL DEFAULT '',
+    pull_request_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    actor_id TEXT NOT NULL DEFAULT '',
+    old_reviewer_id TEXT NOT NULL DEFAULT '',
+    new_reviewer_id TEXT NOT NULL DEFAULT '',
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (repository, pull_request_id) REFERENCES pull_requests(repository, id)
+);
+
+CREATE INDEX pr_events_pr_idx ON pr_events (repository, pull_request_id, id);
@@ -0,0 +1,5 @@
+ALTER TABLE teams
+    ADD COLUMN review_sla_seconds BIGINT NOT NULL DEFAULT 0
+        CHECK (review_sla_seconds >= 0),
+    ADD COLUMN sla_action TEXT NOT NULL DEFAULT 'reassign'
+        CHECK (sla_action IN ('reassign', 'add_reviewer'));
@@ -0,0 +1,6 @@
+CREATE TABLE external_identities (
+    provider TEXT NOT NULL CHECK (provider IN ('github', 'gitlab')),
+    login TEXT NOT NULL,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    PRIMARY KEY (provider, login)
+);
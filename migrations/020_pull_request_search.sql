@@ -0,0 +1,8 @@
+CREATE INDEX pull_requests_created_at_idx ON pull_requests (created_at, repository, id);
+CREATE INDEX pull_requests_updated_at_idx ON pull_requests (updated_at, repository, id);
+CREATE INDEX pull_requests_author_idx ON pull_requests (author_id);
+
+-- Searching by reviewer covers every status, which the partial index from
+-- 002 cannot serve; the full index still serves the open review counts.
+DROP INDEX pull_requests_open_reviewers_idx;
+CREATE INDEX pull_requests_reviewers_idx ON pull_requests USING GIN (reviewers);